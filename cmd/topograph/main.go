@@ -52,19 +52,23 @@ func main() {
 		os.Exit(0)
 	}
 
-	k8s.InitLabels(labelAccelerator, labelLeaf, labelSpine, labelCore)
-
-	if err := mainInternal(cfg); err != nil {
+	// the label keys set on the command line are the defaults of the engine "labels" parameter
+	labelKeys, err := k8s.NewLabelKeys(labelAccelerator, labelLeaf, labelSpine, labelCore)
+	if err == nil {
+		err = mainInternal(cfg, labelKeys)
+	}
+	if err != nil {
 		klog.Error(err.Error())
 		os.Exit(1)
 	}
 }
 
-func mainInternal(c string) error {
+func mainInternal(c string, labelKeys k8s.LabelKeys) error {
 	cfg, err := config.NewFromFile(c)
 	if err != nil {
 		return err
 	}
+	cfg.LabelKeys = &labelKeys
 
 	if err = cfg.UpdateEnv(); err != nil {
 		return err
@@ -18,78 +18,194 @@ package cluset
 
 import (
 	"fmt"
-	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 )
 
-var compactRegexp, expandRegexp *regexp.Regexp
+// maxNumberLen is the length of the longest digit run treated as a number;
+// longer runs are kept as text
+const maxNumberLen = 18
 
-func init() {
-	compactRegexp = regexp.MustCompile(`^(.*?)(0*\d+)$`)
-	expandRegexp = regexp.MustCompile(`^(.*?)\[(.*?)\]$`)
+// maxExpansion limits the number of hosts expanded from a single hostlist entry;
+// larger entries are returned unexpanded
+var maxExpansion = 1 << 20
+
+// hostGroup holds the numeric fields of the host names sharing the same text fields
+type hostGroup struct {
+	first string       // lexicographically first host name
+	texts []string     // text fields surrounding the numeric fields
+	rows  [][][]string // value sets of the numeric fields
 }
 
-// Compact compresses a list of nodes into a compact range format
+// Compact compresses a list of nodes into the hostlist format.
+// The numeric fields of the node names with the same text fields are folded into
+// bracketed ranges, starting from the last field, e.g., "rack1n01", "rack1n02",
+// "rack2n01", and "rack2n02" are compacted into "rack[1-2]n[01-02]".
 func Compact(nodes []string) []string {
-	groups := make(map[string][]string)
+	groups := make(map[string]*hostGroup)
+	keys := []string{}
 
 	// Parse node names into groups
 	for _, node := range nodes {
-		matches := compactRegexp.FindStringSubmatch(node)
-		if matches != nil {
-			prefix, numStr := matches[1], matches[2]
-			groups[prefix] = append(groups[prefix], numStr)
-		} else {
-			groups[node] = nil
+		texts, nums := splitHost(node)
+		key := strings.Join(texts, "\x00")
+		group, ok := groups[key]
+		if !ok {
+			group = &hostGroup{first: node, texts: texts}
+			groups[key] = group
+			keys = append(keys, key)
+		} else if node < group.first {
+			group.first = node
+		}
+		row := make([][]string, len(nums))
+		for i, num := range nums {
+			row[i] = []string{num}
 		}
+		group.rows = append(group.rows, row)
 	}
 
-	// Sort prefixes for consistent output
-	prefixes := make([]string, 0, len(groups))
-	for prefix := range groups {
-		prefixes = append(prefixes, prefix)
-	}
-	sort.Strings(prefixes)
+	// Sort groups for consistent output
+	sort.Slice(keys, func(i, j int) bool {
+		return groups[keys[i]].first < groups[keys[j]].first
+	})
 
-	// Format compressed ranges
 	var result []string
-	for _, prefix := range prefixes {
-		numbers := groups[prefix]
-		sort.Slice(numbers, func(i, j int) bool {
-			return atoi(numbers[i]) < atoi(numbers[j])
-		})
-		compressed := compressRange(numbers)
-		result = append(result, fmt.Sprintf("%s%s", prefix, compressed))
+	for _, key := range keys {
+		result = append(result, groups[key].fold()...)
 	}
 
 	return result
 }
 
-// Expand decompresses a list of compacted node names back to individual entries
-func Expand(compressed []string) []string {
-	var result []string
+// fold merges the rows of the group one numeric field at a time, from the last
+// to the first, and returns the formatted hostlist entries
+func (g *hostGroup) fold() []string {
+	dims := len(g.texts) - 1
+	if dims == 0 {
+		return []string{g.texts[0]}
+	}
 
-	for _, entry := range compressed {
-		matches := expandRegexp.FindStringSubmatch(entry)
-		if matches != nil {
-			prefix, ranges := matches[1], matches[2]
-			rangesParts := strings.Split(ranges, ",")
-			for _, part := range rangesParts {
-				if strings.Contains(part, "-") {
-					bounds := strings.Split(part, "-")
-					width := len(bounds[0]) // Preserve leading zeros
-					for i := atoi(bounds[0]); i <= atoi(bounds[1]); i++ {
-						result = append(result, fmt.Sprintf("%s%0*d", prefix, width, i))
-					}
-				} else {
-					result = append(result, fmt.Sprintf("%s%s", prefix, part))
-				}
+	rows := g.rows
+	for k := dims - 1; k >= 0; k-- {
+		merged := make(map[string][][]string)
+		keys := []string{}
+		for _, row := range rows {
+			key := rowKey(row, k)
+			if m, ok := merged[key]; ok {
+				m[k] = append(m[k], row[k]...)
+			} else {
+				merged[key] = append([][]string(nil), row...)
+				keys = append(keys, key)
+			}
+		}
+		rows = make([][][]string, 0, len(keys))
+		for _, key := range keys {
+			row := merged[key]
+			row[k] = sortValues(row[k])
+			rows = append(rows, row)
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		for k := 0; k < dims; k++ {
+			if c := compareValues(rows[i][k][0], rows[j][k][0]); c != 0 {
+				return c < 0
 			}
+		}
+		return false
+	})
+
+	result := make([]string, 0, len(rows))
+	for _, row := range rows {
+		var sb strings.Builder
+		sb.WriteString(g.texts[0])
+		for k, values := range row {
+			sb.WriteString(compressRange(values))
+			sb.WriteString(g.texts[k+1])
+		}
+		result = append(result, sb.String())
+	}
+
+	return result
+}
+
+// rowKey returns the key of the row fields other than the k-th one
+func rowKey(row [][]string, k int) string {
+	parts := make([]string, 0, len(row))
+	for i, values := range row {
+		if i != k {
+			parts = append(parts, compressRange(values))
+		}
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// sortValues sorts the numbers by value and removes duplicates
+func sortValues(values []string) []string {
+	sort.Slice(values, func(i, j int) bool {
+		return compareValues(values[i], values[j]) < 0
+	})
+	result := values[:0]
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func compareValues(a, b string) int {
+	if na, nb := atoi(a), atoi(b); na != nb {
+		if na < nb {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// splitHost splits the host name into the text fields and the numeric fields between them.
+// Host names containing the hostlist delimiters are not split.
+func splitHost(name string) ([]string, []string) {
+	if strings.ContainsAny(name, "[],") {
+		return []string{name}, nil
+	}
+
+	var texts, nums []string
+	var text strings.Builder
+	for i := 0; i < len(name); {
+		if !isDigit(name[i]) {
+			text.WriteByte(name[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(name) && isDigit(name[j]) {
+			j++
+		}
+		if run := name[i:j]; len(run) <= maxNumberLen {
+			texts = append(texts, text.String())
+			text.Reset()
+			nums = append(nums, run)
 		} else {
-			result = append(result, entry)
+			text.WriteString(run)
 		}
+		i = j
+	}
+	texts = append(texts, text.String())
+
+	return texts, nums
+}
+
+// Expand decompresses a list of compacted node names back to individual entries.
+// An entry may contain several bracketed range lists, e.g., "gpu-a[01-04]-n[1-8]",
+// with the ranges in the form "N", "N-M", or "N-M/step". Entries that are not valid
+// hostlists are returned unchanged.
+func Expand(compressed []string) []string {
+	var result []string
+	for _, entry := range compressed {
+		result = append(result, expandEntry(entry)...)
 	}
 	return result
 }
@@ -99,7 +215,110 @@ func ExpandList(compressed string) []string {
 	return Expand(splitList(compressed))
 }
 
-// compressRange converts a list of numbers into a compact range format
+func expandEntry(entry string) []string {
+	texts, sets, ok := parseEntry(entry)
+	if !ok {
+		return []string{entry}
+	}
+
+	count := 1
+	for _, set := range sets {
+		count *= len(set)
+		if count > maxExpansion {
+			return []string{entry}
+		}
+	}
+
+	// the leftmost range varies slowest
+	hosts := []string{texts[0]}
+	for i, set := range sets {
+		next := make([]string, 0, len(hosts)*len(set))
+		for _, host := range hosts {
+			for _, value := range set {
+				next = append(next, host+value+texts[i+1])
+			}
+		}
+		hosts = next
+	}
+
+	return hosts
+}
+
+// parseEntry splits the hostlist entry into the text fields and the expanded range lists between them
+func parseEntry(entry string) ([]string, [][]string, bool) {
+	var texts []string
+	var sets [][]string
+	for {
+		i := strings.IndexByte(entry, '[')
+		if i < 0 {
+			break
+		}
+		j := strings.IndexByte(entry[i:], ']')
+		if j < 0 {
+			return nil, nil, false
+		}
+		set, ok := parseRanges(entry[i+1 : i+j])
+		if !ok {
+			return nil, nil, false
+		}
+		texts = append(texts, entry[:i])
+		sets = append(sets, set)
+		entry = entry[i+j+1:]
+	}
+	texts = append(texts, entry)
+
+	for _, text := range texts {
+		if strings.ContainsAny(text, "],") {
+			return nil, nil, false
+		}
+	}
+
+	return texts, sets, true
+}
+
+// parseRanges expands the comma-separated range list, preserving the width of the lower bounds
+func parseRanges(ranges string) ([]string, bool) {
+	var values []string
+	for _, part := range strings.Split(ranges, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		lo, hi, isRange := strings.Cut(rng, "-")
+		if !isNumber(lo) {
+			return nil, false
+		}
+		if !isRange {
+			if hasStep {
+				return nil, false
+			}
+			values = append(values, lo)
+			continue
+		}
+		if !isNumber(hi) {
+			return nil, false
+		}
+		step := 1
+		if hasStep {
+			if !isNumber(stepStr) {
+				return nil, false
+			}
+			if step = atoi(stepStr); step == 0 {
+				return nil, false
+			}
+		}
+		start, end := atoi(lo), atoi(hi)
+		if end < start || len(values)+(end-start)/step >= maxExpansion {
+			return nil, false
+		}
+		width := len(lo) // Preserve leading zeros
+		for i := start; i <= end; i += step {
+			values = append(values, fmt.Sprintf("%0*d", width, i))
+		}
+	}
+	return values, true
+}
+
+// compressRange converts a sorted list of numbers into a compact range format.
+// Consecutive numbers are joined into a range only if they keep the width of its
+// lower bound, so that the range expands back into the same strings.
 func compressRange(numbers []string) string {
 	switch len(numbers) {
 	case 0:
@@ -111,7 +330,7 @@ func compressRange(numbers []string) string {
 		start, end := numbers[0], numbers[0]
 
 		for i := 1; i < len(numbers); i++ {
-			if atoi(numbers[i]) == atoi(end)+1 {
+			if n := atoi(numbers[i]); n == atoi(end)+1 && fmt.Sprintf("%0*d", len(start), n) == numbers[i] {
 				end = numbers[i]
 			} else {
 				parts = append(parts, formatRange(start, end))
@@ -138,6 +357,23 @@ func atoi(s string) int {
 	return num
 }
 
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isNumber checks if the string is a non-empty digit run of at most maxNumberLen digits
+func isNumber(s string) bool {
+	if len(s) == 0 || len(s) > maxNumberLen {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // splitList splits a string of node names by commas, while preserving ranges
 func splitList(list string) []string {
 	var result []string
@@ -17,7 +17,9 @@
 package cluset
 
 import (
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/require"
 )
@@ -50,6 +52,29 @@ func TestCompactExpand(t *testing.T) {
 			expanded:  []string{"abc0507", "abc0509", "abc0508", "abc0482", "xyz8", "xyz9", "xyz10"},
 			compacted: []string{"abc[0482,0507-0509]", "xyz[8-10]"},
 		},
+		{
+			name:      "Case 6: suffixes",
+			expanded:  []string{"node001-ib", "node002-ib", "node003-ib", "node001", "node002"},
+			compacted: []string{"node[001-002]", "node[001-003]-ib"},
+		},
+		{
+			name: "Case 7: multiple numeric fields",
+			expanded: []string{
+				"gpu-a01-n1", "gpu-a01-n2", "gpu-a02-n1", "gpu-a02-n2",
+				"gpu-a03-n1", "gpu-a03-n2", "gpu-a04-n1", "gpu-a04-n2",
+			},
+			compacted: []string{"gpu-a[01-04]-n[1-2]"},
+		},
+		{
+			name:      "Case 8: partially populated fields",
+			expanded:  []string{"rack1n01", "rack1n02", "rack1n03", "rack2n01", "rack2n02", "rack3n01", "rack3n02"},
+			compacted: []string{"rack1n[01-03]", "rack[2-3]n[01-02]"},
+		},
+		{
+			name:      "Case 9: mixed widths",
+			expanded:  []string{"n9", "n010", "n011", "n1", "n01"},
+			compacted: []string{"n[01,1,9,010-011]"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -79,6 +104,16 @@ func TestExpandList(t *testing.T) {
 			input:  "dgx[0001-0018,0037-0054],dgx[0055-0072],dgx[0075,0090],dgx0127",
 			output: []string{"dgx[0001-0018,0037-0072,0075,0090,0127]"},
 		},
+		{
+			name:   "Case 4: multiple ranges",
+			input:  "rack[1-2]n[01-32],rack3n[01-16]",
+			output: []string{"rack[1-2]n[01-32]", "rack3n[01-16]"},
+		},
+		{
+			name:   "Case 5: step ranges",
+			input:  "node[1-9/2,2-10/4],node[4,8]",
+			output: []string{"node[1-10]"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -88,3 +123,120 @@ func TestExpandList(t *testing.T) {
 		})
 	}
 }
+
+func TestExpand(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		output []string
+	}{
+		{
+			name:   "Case 1: suffix",
+			input:  "node[08-10]-ib",
+			output: []string{"node08-ib", "node09-ib", "node10-ib"},
+		},
+		{
+			name:   "Case 2: multiple ranges",
+			input:  "r[1-2]n[1,3]",
+			output: []string{"r1n1", "r1n3", "r2n1", "r2n3"},
+		},
+		{
+			name:   "Case 3: step range",
+			input:  "n[001-010/3]",
+			output: []string{"n001", "n004", "n007", "n010"},
+		},
+		{
+			name:   "Case 4: unclosed bracket",
+			input:  "n[1-2",
+			output: []string{"n[1-2"},
+		},
+		{
+			name:   "Case 5: reverse range",
+			input:  "n[2-1]",
+			output: []string{"n[2-1]"},
+		},
+		{
+			name:   "Case 6: invalid range",
+			input:  "n[a-b]",
+			output: []string{"n[a-b]"},
+		},
+		{
+			name:   "Case 7: zero step",
+			input:  "n[1-4/0]",
+			output: []string{"n[1-4/0]"},
+		},
+		{
+			name:   "Case 8: too many hosts",
+			input:  "n[0-999][0-999][0-999]",
+			output: []string{"n[0-999][0-999][0-999]"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.output, ExpandList(tc.input))
+		})
+	}
+}
+
+// validNames filters out the names that cannot be represented in a hostlist
+func validNames(names []string) []string {
+	var valid []string
+	for _, name := range names {
+		if len(name) != 0 && !strings.ContainsAny(name, "[], \t\n\r\v\f\x00") && utf8.ValidString(name) {
+			valid = append(valid, name)
+		}
+	}
+	return valid
+}
+
+func requireSameSet(t *testing.T, expected, actual []string) {
+	expectedSet := make(map[string]bool)
+	for _, name := range expected {
+		expectedSet[name] = true
+	}
+	actualSet := make(map[string]bool)
+	for _, name := range actual {
+		actualSet[name] = true
+	}
+	require.Equal(t, expectedSet, actualSet)
+}
+
+func FuzzCompactExpand(f *testing.F) {
+	for _, seed := range []string{
+		"abc0507 abc0509 abc0482 124 abc0483",
+		"node001-ib node002-ib node001",
+		"gpu-a01-n1 gpu-a01-n2 gpu-a02-n1 gpu-a02-n2",
+		"rack1n01 rack1n02 rack2n01 n9 n010 n1 n01",
+		"S-2c5eab0300b879c0 S-2c5eab0300b87a80",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data string) {
+		nodes := validNames(strings.Fields(data))
+		compacted := Compact(nodes)
+		requireSameSet(t, nodes, Expand(compacted))
+		require.Equal(t, compacted, Compact(Expand(compacted)))
+	})
+}
+
+func FuzzExpandCompact(f *testing.F) {
+	for _, seed := range []string{
+		"dgx[0001-0018,0037-0054],dgx[0055-0072],dgx0127",
+		"gpu-a[01-04]-n[1-8]",
+		"rack[1-2]n[01-32],node001-ib",
+		"node[1-9/2,2-10/4]",
+		"n[1-2",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, list string) {
+		defer func(n int) { maxExpansion = n }(maxExpansion)
+		maxExpansion = 1024
+
+		nodes := validNames(ExpandList(list))
+		requireSameSet(t, nodes, Expand(Compact(nodes)))
+	})
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionNodesLabeled tells whether the topology node labels were applied
+	ConditionNodesLabeled = "NodesLabeled"
+
+	ReasonLabelsApplied  = "LabelsApplied"
+	ReasonLabelingFailed = "LabelingFailed"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=ctopo
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Tiers",type=integer,JSONPath=`.status.tierCount`
+// +kubebuilder:printcolumn:name="Switches",type=integer,JSONPath=`.status.switchCount`
+// +kubebuilder:printcolumn:name="Domains",type=integer,JSONPath=`.status.acceleratorDomainCount`
+// +kubebuilder:printcolumn:name="Nodes",type=integer,JSONPath=`.status.nodeCount`
+// +kubebuilder:printcolumn:name="Generated",type=date,JSONPath=`.status.lastGenerated`
+
+// ClusterTopology is the network topology of the cluster as discovered by Topograph:
+// the switch tiers, the switches with their member nodes, and the accelerator domains.
+// It gives a global view of the topology that the node labels carry per node.
+type ClusterTopology struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterTopologySpec   `json:"spec,omitempty"`
+	Status ClusterTopologyStatus `json:"status,omitempty"`
+}
+
+// ClusterTopologySpec is the discovered topology
+type ClusterTopologySpec struct {
+	// Tiers are the switch tiers ordered from the top tier down to the leaf switches
+	// +optional
+	Tiers []Tier `json:"tiers,omitempty"`
+	// Switches are the switches ordered by tier from the top tier down, then by name
+	// +optional
+	Switches []Switch `json:"switches,omitempty"`
+	// AcceleratorDomains are the accelerator domains, such as NVLink domains, ordered by name
+	// +optional
+	AcceleratorDomains []AcceleratorDomain `json:"acceleratorDomains,omitempty"`
+}
+
+// Tier is a switch tier
+type Tier struct {
+	// Level is the height of the tier above the compute nodes, starting from 1 for leaf switches
+	Level int32 `json:"level"`
+	// Name is the tier name: leaf, spine, core, or tier<level> above the core tier
+	Name string `json:"name"`
+	// NodeLabel is the key of the node label holding the switch of this tier,
+	// empty for the tiers above the labeled ones and when the topology is written as node annotations
+	// +optional
+	NodeLabel string `json:"nodeLabel,omitempty"`
+}
+
+// Switch is a network switch
+type Switch struct {
+	// Name is the switch name under the naming strategy, as in the node labels
+	Name string `json:"name"`
+	// ID is the provider switch ID, if different from the name
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Tier is the level of the switch tier
+	Tier int32 `json:"tier"`
+	// Parent is the name of the upper-tier switch
+	// +optional
+	Parent string `json:"parent,omitempty"`
+	// Children are the names of the lower-tier switches
+	// +optional
+	Children []string `json:"children,omitempty"`
+	// Nodes are the names of the compute nodes connected to the switch, ordered by name
+	// +optional
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// AcceleratorDomain is a high-speed interconnect domain, such as an NVLink domain
+type AcceleratorDomain struct {
+	// Name is the domain name, as in the accelerator node label
+	Name string `json:"name"`
+	// Nodes are the names of the compute nodes in the domain, ordered by name
+	Nodes []string `json:"nodes"`
+}
+
+// ClusterTopologyStatus describes the last topology generation
+type ClusterTopologyStatus struct {
+	// ObservedGeneration is the metadata generation of the spec described by the status
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastGenerated is the time of the last topology generation
+	// +optional
+	LastGenerated metav1.Time `json:"lastGenerated,omitempty"`
+	// TopographVersion is the version of Topograph that generated the topology
+	// +optional
+	TopographVersion string `json:"topographVersion,omitempty"`
+	// TierCount is the number of switch tiers
+	// +optional
+	TierCount int32 `json:"tierCount,omitempty"`
+	// SwitchCount is the number of switches
+	// +optional
+	SwitchCount int32 `json:"switchCount,omitempty"`
+	// AcceleratorDomainCount is the number of accelerator domains
+	// +optional
+	AcceleratorDomainCount int32 `json:"acceleratorDomainCount,omitempty"`
+	// NodeCount is the number of compute nodes in the topology
+	// +optional
+	NodeCount int32 `json:"nodeCount,omitempty"`
+	// Conditions are the latest observations of the topology state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterTopologyList is a list of ClusterTopology objects
+type ClusterTopologyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterTopology `json:"items"`
+}
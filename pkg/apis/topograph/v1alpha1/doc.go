@@ -0,0 +1,9 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package v1alpha1 contains the v1alpha1 API of the topograph.nvidia.com group
+// +kubebuilder:object:generate=true
+// +groupName=topograph.nvidia.com
+package v1alpha1
@@ -25,6 +25,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/NVIDIA/topograph/internal/files"
+	"github.com/NVIDIA/topograph/pkg/engines/k8s"
 	"github.com/NVIDIA/topograph/pkg/registry"
 )
 
@@ -42,6 +43,8 @@ type Config struct {
 
 	// derived
 	Credentials map[string]any
+	// LabelKeys are the default topology label keys of the k8s and kueue engines, set from the command line
+	LabelKeys *k8s.LabelKeys `yaml:"-"`
 }
 
 type Endpoint struct {
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package flux
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/files"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+const (
+	NAME = "flux"
+
+	DefaultClusterName = "cluster"
+)
+
+type FluxEngine struct {
+	params *Params
+}
+
+type Params struct {
+	// ClusterName is the basename of the cluster vertex
+	ClusterName string `mapstructure:"clusterName"`
+	// Cores and GPUs are the resources of every compute node
+	Cores int `mapstructure:"cores"`
+	GPUs  int `mapstructure:"gpus"`
+	// NodeResources overrides the resources of individual compute nodes
+	NodeResources      map[string]*translate.NodeResources `mapstructure:"nodeResources"`
+	TopologyConfigPath string                              `mapstructure:"topologyConfigPath"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(_ context.Context, params engines.Config) (engines.Engine, *httperr.Error) {
+	p, err := getParameters(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return &FluxEngine{
+		params: p,
+	}, nil
+}
+
+func getParameters(params engines.Config) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	if len(p.ClusterName) == 0 {
+		p.ClusterName = DefaultClusterName
+	}
+
+	if p.Cores < 0 || p.GPUs < 0 {
+		return nil, fmt.Errorf("cores and gpus must not be negative")
+	}
+	for nodeName, res := range p.NodeResources {
+		if res == nil || res.Cores < 0 || res.GPUs < 0 {
+			return nil, fmt.Errorf("invalid resources for node %q", nodeName)
+		}
+	}
+
+	return p, nil
+}
+
+// resources returns the resources of the compute node
+func (p *Params) resources(nodeName string) *translate.NodeResources {
+	if res, ok := p.NodeResources[nodeName]; ok {
+		return res
+	}
+	return &translate.NodeResources{Cores: p.Cores, GPUs: p.GPUs}
+}
+
+func (eng *FluxEngine) GenerateOutput(_ context.Context, graph *topology.Graph, _ map[string]any) ([]byte, *httperr.Error) {
+	jgf, err := translate.NewJGF(graph, &translate.JGFConfig{
+		ClusterName: eng.params.ClusterName,
+		Resources:   eng.params.resources,
+	})
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	data, err := json.MarshalIndent(jgf, "", "  ")
+	if err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+	data = append(data, '\n')
+
+	if len(eng.params.TopologyConfigPath) == 0 {
+		return data, nil
+	}
+
+	if err := files.Create(eng.params.TopologyConfigPath, data); err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return []byte("OK\n"), nil
+}
+
+func (eng *FluxEngine) GetComputeInstances(_ context.Context, _ any) ([]topology.ComputeInstances, *httperr.Error) {
+	return nil, httperr.NewError(http.StatusBadRequest,
+		"flux engine requires nodes in the request or a provider that can supply compute instances")
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package flux
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+func TestNamedLoader(t *testing.T) {
+	name, _ := NamedLoader()
+	require.Equal(t, NAME, name)
+}
+
+func TestGetParameters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]any
+		want   *Params
+		err    string
+	}{
+		{
+			name:   "Case 1: default cluster name",
+			params: nil,
+			want:   &Params{ClusterName: DefaultClusterName},
+		},
+		{
+			name: "Case 2: valid params",
+			params: map[string]any{
+				"clusterName":        "dgx",
+				"cores":              112,
+				"gpus":               8,
+				"nodeResources":      map[string]any{"login": map[string]any{"cores": 32}},
+				"topologyConfigPath": "/tmp/x",
+			},
+			want: &Params{
+				ClusterName:        "dgx",
+				Cores:              112,
+				GPUs:               8,
+				NodeResources:      map[string]*translate.NodeResources{"login": {Cores: 32}},
+				TopologyConfigPath: "/tmp/x",
+			},
+		},
+		{
+			name:   "Case 3: negative count",
+			params: map[string]any{"gpus": -1},
+			err:    "cores and gpus must not be negative",
+		},
+		{
+			name:   "Case 4: invalid node resources",
+			params: map[string]any{"nodeResources": map[string]any{"n1": map[string]any{"cores": -2}}},
+			err:    `invalid resources for node "n1"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := getParameters(tc.params)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGenerateOutput(t *testing.T) {
+	ctx := context.Background()
+	graph, _ := translate.GetTreeTestSet(false)
+	params := &Params{
+		ClusterName:   DefaultClusterName,
+		Cores:         2,
+		GPUs:          1,
+		NodeResources: map[string]*translate.NodeResources{"Node201": {}},
+	}
+
+	out, herr := (&FluxEngine{params: params}).GenerateOutput(ctx, graph, nil)
+	require.Nil(t, herr)
+
+	var jgf translate.JGF
+	require.NoError(t, json.Unmarshal(out, &jgf))
+	counts := make(map[string]int)
+	for _, v := range jgf.Graph.Nodes {
+		counts[v.Metadata.Type]++
+	}
+	// Node201 has no cores and GPUs
+	require.Equal(t, map[string]int{
+		translate.JGFTypeCluster: 1,
+		translate.JGFTypeSwitch:  3,
+		translate.JGFTypeNode:    6,
+		translate.JGFTypeCore:    10,
+		translate.JGFTypeGPU:     5,
+	}, counts)
+
+	// write to file
+	params.TopologyConfigPath = filepath.Join(t.TempDir(), "resources.json")
+	out, herr = (&FluxEngine{params: params}).GenerateOutput(ctx, graph, nil)
+	require.Nil(t, herr)
+	require.Equal(t, "OK\n", string(out))
+	data, err := os.ReadFile(params.TopologyConfigPath)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &jgf))
+
+	_, herr = (&FluxEngine{params: params}).GenerateOutput(ctx, &topology.Graph{}, nil)
+	require.NotNil(t, herr)
+	require.Equal(t, http.StatusBadRequest, herr.Code())
+}
+
+func TestGetComputeInstances(t *testing.T) {
+	eng := &FluxEngine{params: &Params{}}
+	cis, herr := eng.GetComputeInstances(context.Background(), nil)
+	require.Nil(t, cis)
+	require.NotNil(t, herr)
+	require.Equal(t, http.StatusBadRequest, herr.Code())
+}
@@ -7,15 +7,20 @@ package graph
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"maps"
 	"net/http"
 	"slices"
+	"time"
+
+	"sigs.k8s.io/yaml"
 
 	"github.com/NVIDIA/topograph/internal/config"
 	"github.com/NVIDIA/topograph/internal/files"
 	"github.com/NVIDIA/topograph/internal/httperr"
 	"github.com/NVIDIA/topograph/pkg/engines"
 	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
 )
 
 const NAME = "graph"
@@ -26,6 +31,10 @@ type GraphEngine struct {
 
 type Params struct {
 	TopologyConfigPath string `mapstructure:"topologyConfigPath"`
+	// Format is the document format: json or yaml
+	Format string `mapstructure:"format"`
+	// IncludeTopology outputs the TopologyGraph document with the switch tiers and the accelerator domains
+	IncludeTopology bool `mapstructure:"includeTopology"`
 }
 
 func NamedLoader() (string, engines.Loader) {
@@ -49,21 +58,32 @@ func getParameters(params engines.Config) (*Params, error) {
 		return nil, err
 	}
 
+	switch p.Format {
+	case "", topology.FormatJSON, topology.FormatYAML:
+		// nop
+	default:
+		return nil, fmt.Errorf("unsupported format %q", p.Format)
+	}
+
 	return p, nil
 }
 
 func (eng *GraphEngine) GenerateOutput(_ context.Context, graph *topology.Graph, _ map[string]any) ([]byte, *httperr.Error) {
-	doc := makeInstancesDocument(graphInstances(graph))
-	data, err := json.Marshal(doc)
+	params := eng.params
+	if params == nil {
+		params = &Params{}
+	}
+
+	data, err := marshal(graph, params)
 	if err != nil {
 		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
 	}
 
-	if eng.params == nil || eng.params.TopologyConfigPath == "" {
+	if params.TopologyConfigPath == "" {
 		return data, nil
 	}
 
-	if err := files.Create(eng.params.TopologyConfigPath, data); err != nil {
+	if err := files.Create(params.TopologyConfigPath, data); err != nil {
 		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
 	}
 
@@ -75,11 +95,52 @@ func (eng *GraphEngine) GetComputeInstances(_ context.Context, _ any) ([]topolog
 		"graph engine requires nodes in the request or a provider that can supply compute instances")
 }
 
+// marshal returns the instances document, or the TopologyGraph document if the topology is included
+func marshal(graph *topology.Graph, params *Params) ([]byte, error) {
+	instances := graphInstances(graph)
+
+	if params.IncludeTopology {
+		doc := &topology.Graph{Instances: instances}
+		if graph != nil {
+			doc.Tiers = graph.Tiers
+			doc.Domains = graph.Domains
+		}
+		return topology.Marshal(doc, topology.GraphMetadata{Timestamp: time.Now().UTC()}, params.Format)
+	}
+
+	doc := makeInstancesDocument(instances)
+	if params.Format == topology.FormatYAML {
+		return yaml.Marshal(doc)
+	}
+	return json.Marshal(doc)
+}
+
+// graphInstances returns the instances supplied by the provider, if any.
+// Otherwise, the instances are derived from the switch tiers and the accelerator domains.
 func graphInstances(graph *topology.Graph) map[string]topology.Instance {
 	if graph == nil {
 		return nil
 	}
-	return graph.Instances
+	if len(graph.Instances) != 0 {
+		return graph.Instances
+	}
+
+	instances := make(map[string]topology.Instance)
+	for _, placement := range translate.GetNodePlacements(graph) {
+		if len(placement.InstanceID) == 0 {
+			continue
+		}
+		instance := topology.Instance{
+			ID:            placement.InstanceID,
+			NetworkLayers: append([]string{}, placement.Switches...),
+		}
+		if len(placement.Domain) != 0 {
+			instance.Labels = map[string]string{topology.KeyTopologyAccelerator: placement.Domain}
+		}
+		instances[placement.InstanceID] = instance
+	}
+
+	return instances
 }
 
 func makeInstancesDocument(instances map[string]topology.Instance) *topology.Instances {
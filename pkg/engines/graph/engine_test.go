@@ -12,8 +12,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
 
 	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
 )
 
 func TestNamedLoader(t *testing.T) {
@@ -26,6 +28,7 @@ func TestGetParameters(t *testing.T) {
 		name   string
 		params map[string]any
 		want   *Params
+		err    string
 	}{
 		{
 			name:   "empty params",
@@ -37,13 +40,27 @@ func TestGetParameters(t *testing.T) {
 			params: map[string]any{"topologyConfigPath": "/var/lib/graph/out.json"},
 			want:   &Params{TopologyConfigPath: "/var/lib/graph/out.json"},
 		},
+		{
+			name:   "format and topology",
+			params: map[string]any{"format": "yaml", "includeTopology": true},
+			want:   &Params{Format: topology.FormatYAML, IncludeTopology: true},
+		},
+		{
+			name:   "invalid format",
+			params: map[string]any{"format": "xml"},
+			err:    `unsupported format "xml"`,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			got, err := getParameters(tc.params)
-			require.NoError(t, err)
-			require.Equal(t, tc.want, got)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.want, got)
+			}
 		})
 	}
 }
@@ -122,6 +139,47 @@ func TestGenerateOutput(t *testing.T) {
 	})
 }
 
+func TestGenerateDerivedOutput(t *testing.T) {
+	ctx := context.Background()
+	graph, _ := translate.GetBlockWithMultiIBTestSet()
+
+	t.Run("instances derived from tiers and domains", func(t *testing.T) {
+		eng := &GraphEngine{params: &Params{}}
+		out, herr := eng.GenerateOutput(ctx, graph, nil)
+		require.Nil(t, herr)
+		var doc topology.Instances
+		require.NoError(t, json.Unmarshal(out, &doc))
+		require.Len(t, doc.Instances, 12)
+		require.Equal(t, topology.Instance{
+			ID:            "I15",
+			NetworkLayers: []string{"S2", "S1", "IB2"},
+			Labels:        map[string]string{topology.KeyTopologyAccelerator: "B1"},
+		}, doc.Instances[1])
+	})
+
+	t.Run("yaml instances", func(t *testing.T) {
+		eng := &GraphEngine{params: &Params{Format: topology.FormatYAML}}
+		out, herr := eng.GenerateOutput(ctx, graph, nil)
+		require.Nil(t, herr)
+		var doc topology.Instances
+		require.NoError(t, yaml.Unmarshal(out, &doc))
+		require.Len(t, doc.Instances, 12)
+	})
+
+	t.Run("topology document", func(t *testing.T) {
+		for _, format := range []string{topology.FormatJSON, topology.FormatYAML} {
+			eng := &GraphEngine{params: &Params{Format: format, IncludeTopology: true}}
+			out, herr := eng.GenerateOutput(ctx, graph, nil)
+			require.Nil(t, herr)
+			got, _, err := topology.Unmarshal(out)
+			require.NoError(t, err)
+			require.Len(t, got.Instances, 12)
+			require.Equal(t, graph.Domains, got.Domains)
+			require.Len(t, got.Tiers.Vertices, 2)
+		}
+	})
+}
+
 func TestGetComputeInstances(t *testing.T) {
 	eng := &GraphEngine{params: &Params{}}
 	cis, herr := eng.GetComputeInstances(context.Background(), nil)
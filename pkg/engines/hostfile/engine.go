@@ -0,0 +1,131 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package hostfile
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/files"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+const (
+	NAME = "hostfile"
+
+	// FormatHostfile is the OpenMPI hostfile: "<node> slots=<slots>"
+	FormatHostfile = "hostfile"
+	// FormatRankfile is the OpenMPI rankfile: "rank <rank>=<node> slot=<slot>"
+	FormatRankfile = "rankfile"
+	// FormatMachinefile is the MPICH machinefile: "<node>:<slots>"
+	FormatMachinefile = "machinefile"
+	// FormatList is the plain list of node names
+	FormatList = "list"
+
+	DefaultSlots = 1
+)
+
+type HostfileEngine struct {
+	params *Params
+}
+
+type Params struct {
+	Format string `mapstructure:"format"`
+	// Slots is the number of ranks per node
+	Slots              int    `mapstructure:"slots"`
+	TopologyConfigPath string `mapstructure:"topologyConfigPath"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(_ context.Context, params engines.Config) (engines.Engine, *httperr.Error) {
+	p, err := getParameters(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return &HostfileEngine{
+		params: p,
+	}, nil
+}
+
+func getParameters(params engines.Config) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	switch p.Format {
+	case "":
+		p.Format = FormatHostfile
+	case FormatHostfile, FormatRankfile, FormatMachinefile, FormatList:
+		// nop
+	default:
+		return nil, fmt.Errorf("unsupported format %q", p.Format)
+	}
+
+	if p.Slots == 0 {
+		p.Slots = DefaultSlots
+	} else if p.Slots < 0 {
+		return nil, fmt.Errorf("slots must be positive")
+	}
+
+	return p, nil
+}
+
+func (eng *HostfileEngine) GenerateOutput(_ context.Context, graph *topology.Graph, _ map[string]any) ([]byte, *httperr.Error) {
+	nodes := translate.GetNodeOrder(graph)
+	if len(nodes) == 0 {
+		return nil, httperr.NewError(http.StatusBadRequest, "no compute nodes in the topology")
+	}
+
+	data := toHostfile(nodes, eng.params)
+
+	if len(eng.params.TopologyConfigPath) == 0 {
+		return data, nil
+	}
+
+	if err := files.Create(eng.params.TopologyConfigPath, data); err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return []byte("OK\n"), nil
+}
+
+// toHostfile writes the ordered nodes in the requested format
+func toHostfile(nodes []string, p *Params) []byte {
+	buf := &bytes.Buffer{}
+	rank := 0
+	for _, node := range nodes {
+		switch p.Format {
+		case FormatHostfile:
+			fmt.Fprintf(buf, "%s slots=%d\n", node, p.Slots)
+		case FormatRankfile:
+			for slot := range p.Slots {
+				fmt.Fprintf(buf, "rank %d=%s slot=%d\n", rank, node, slot)
+				rank++
+			}
+		case FormatMachinefile:
+			fmt.Fprintf(buf, "%s:%d\n", node, p.Slots)
+		default:
+			fmt.Fprintln(buf, node)
+		}
+	}
+	return buf.Bytes()
+}
+
+func (eng *HostfileEngine) GetComputeInstances(_ context.Context, _ any) ([]topology.ComputeInstances, *httperr.Error) {
+	return nil, httperr.NewError(http.StatusBadRequest,
+		"hostfile engine requires nodes in the request or a provider that can supply compute instances")
+}
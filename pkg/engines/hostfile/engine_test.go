@@ -0,0 +1,118 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package hostfile
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+func TestNamedLoader(t *testing.T) {
+	name, _ := NamedLoader()
+	require.Equal(t, NAME, name)
+}
+
+func TestGetParameters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]any
+		want   *Params
+		err    string
+	}{
+		{
+			name:   "Case 1: defaults",
+			params: nil,
+			want:   &Params{Format: FormatHostfile, Slots: DefaultSlots},
+		},
+		{
+			name:   "Case 2: valid params",
+			params: map[string]any{"format": "rankfile", "slots": 8, "topologyConfigPath": "/tmp/x"},
+			want:   &Params{Format: FormatRankfile, Slots: 8, TopologyConfigPath: "/tmp/x"},
+		},
+		{
+			name:   "Case 3: invalid format",
+			params: map[string]any{"format": "csv"},
+			err:    `unsupported format "csv"`,
+		},
+		{
+			name:   "Case 4: negative slots",
+			params: map[string]any{"slots": -1},
+			err:    "slots must be positive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := getParameters(tc.params)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestToHostfile(t *testing.T) {
+	nodes := []string{"n2", "n1"}
+	testCases := []struct {
+		format string
+		out    string
+	}{
+		{format: FormatHostfile, out: "n2 slots=2\nn1 slots=2\n"},
+		{format: FormatRankfile, out: "rank 0=n2 slot=0\nrank 1=n2 slot=1\nrank 2=n1 slot=0\nrank 3=n1 slot=1\n"},
+		{format: FormatMachinefile, out: "n2:2\nn1:2\n"},
+		{format: FormatList, out: "n2\nn1\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			require.Equal(t, tc.out, string(toHostfile(nodes, &Params{Format: tc.format, Slots: 2})))
+		})
+	}
+}
+
+func TestGenerateOutput(t *testing.T) {
+	ctx := context.Background()
+	graph, _ := translate.GetTreeTestSet(false)
+	graph.Domains = topology.DomainMap{
+		"nvl1": {"Node205": {}, "Node304": {}},
+	}
+	params := &Params{Format: FormatList, Slots: DefaultSlots}
+
+	out, herr := (&HostfileEngine{params: params}).GenerateOutput(ctx, graph, nil)
+	require.Nil(t, herr)
+	require.Equal(t, "Node201\nNode202\nNode205\nNode304\nNode305\nNode306\n", string(out))
+
+	params.TopologyConfigPath = filepath.Join(t.TempDir(), "hostfile")
+	out, herr = (&HostfileEngine{params: params}).GenerateOutput(ctx, graph, nil)
+	require.Nil(t, herr)
+	require.Equal(t, "OK\n", string(out))
+	data, err := os.ReadFile(params.TopologyConfigPath)
+	require.NoError(t, err)
+	require.Equal(t, "Node201\nNode202\nNode205\nNode304\nNode305\nNode306\n", string(data))
+
+	_, herr = (&HostfileEngine{params: params}).GenerateOutput(ctx, &topology.Graph{}, nil)
+	require.NotNil(t, herr)
+	require.Equal(t, http.StatusBadRequest, herr.Code())
+}
+
+func TestGetComputeInstances(t *testing.T) {
+	eng := &HostfileEngine{params: &Params{}}
+	cis, herr := eng.GetComputeInstances(context.Background(), nil)
+	require.Nil(t, cis)
+	require.NotNil(t, herr)
+	require.Equal(t, http.StatusBadRequest, herr.Code())
+}
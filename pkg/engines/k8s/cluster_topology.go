@@ -0,0 +1,251 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package k8s
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/version"
+	"github.com/NVIDIA/topograph/pkg/apis/topograph/v1alpha1"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// maxClusterTopologySpecSize is the size limit of the ClusterTopology spec,
+// leaving room for the metadata and status below the 1.5 MiB etcd request limit
+const maxClusterTopologySpecSize = 1 << 20
+
+var (
+	tierNames = []string{"leaf", "spine", "core"}
+
+	errClusterTopologyTooLarge = errors.New("ClusterTopology is too large")
+)
+
+// clusterTopologySpec returns the ClusterTopology spec of the topology graph
+// and the number of compute nodes in the graph
+func clusterTopologySpec(graph *topology.Graph, p *Params) (*v1alpha1.ClusterTopologySpec, int, error) {
+	spec := &v1alpha1.ClusterTopologySpec{}
+	nodes := make(map[string]bool)
+
+	if treeRoot := graph.ToTree().Tiers; treeRoot != nil {
+		names, err := p.Naming.SwitchNames(treeRoot)
+		if err != nil {
+			return nil, 0, err
+		}
+		switchName := func(id string) string {
+			if name, ok := names[id]; ok {
+				return name
+			}
+			return id
+		}
+
+		// visit adds the switches under the vertex, and returns the height of the vertex
+		// above the compute nodes
+		var visit func(v *topology.Vertex, parent string) int
+		visit = func(v *topology.Vertex, parent string) int {
+			if len(v.Vertices) == 0 {
+				nodes[v.Name] = true
+				return 0
+			}
+
+			// the root without ID and the NoTopology switch are not switches
+			isSwitch := len(v.ID) != 0 && v.ID != topology.NoTopology
+			childParent := ""
+			if isSwitch {
+				childParent = switchName(v.ID)
+			}
+
+			height := 0
+			members := []string{}
+			children := []string{}
+			for _, w := range v.Vertices {
+				height = max(height, visit(w, childParent)+1)
+				switch {
+				case len(w.Vertices) == 0:
+					members = append(members, w.Name)
+				case w.ID != topology.NoTopology:
+					children = append(children, switchName(w.ID))
+				}
+			}
+
+			if isSwitch {
+				sw := v1alpha1.Switch{
+					Name:   switchName(v.ID),
+					Tier:   int32(height),
+					Parent: parent,
+				}
+				if sw.Name != v.ID {
+					sw.ID = v.ID
+				}
+				if len(children) != 0 {
+					sw.Children = slices.Sorted(slices.Values(children))
+				}
+				if len(members) != 0 {
+					sw.Nodes = slices.Sorted(slices.Values(members))
+				}
+				spec.Switches = append(spec.Switches, sw)
+			}
+			return height
+		}
+		visit(treeRoot, "")
+	}
+
+	tiers := 0
+	for _, sw := range spec.Switches {
+		tiers = max(tiers, int(sw.Tier))
+	}
+	labelTiers := p.Labels.tiers()
+	for level := tiers; level > 0; level-- {
+		tier := v1alpha1.Tier{Level: int32(level), Name: fmt.Sprintf("tier%d", level)}
+		if level <= len(tierNames) {
+			tier.Name = tierNames[level-1]
+			// no node labels are written in the annotation mode
+			if p.Mode != ModeAnnotations {
+				tier.NodeLabel = labelTiers[level-1]
+			}
+		}
+		spec.Tiers = append(spec.Tiers, tier)
+	}
+
+	slices.SortFunc(spec.Switches, func(a, b v1alpha1.Switch) int {
+		if a.Tier != b.Tier {
+			return cmp.Compare(b.Tier, a.Tier)
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	for _, domainName := range slices.Sorted(maps.Keys(graph.Domains)) {
+		domainNodes := slices.Sorted(maps.Keys(graph.Domains[domainName]))
+		for _, nodeName := range domainNodes {
+			nodes[nodeName] = true
+		}
+		spec.AcceleratorDomains = append(spec.AcceleratorDomains, v1alpha1.AcceleratorDomain{
+			Name:  domainName,
+			Nodes: domainNodes,
+		})
+	}
+
+	return spec, len(nodes), nil
+}
+
+// writeClusterTopology creates or updates the ClusterTopology object with the topology graph,
+// and sets its status with the outcome of the node labeling
+func (eng *K8sEngine) writeClusterTopology(ctx context.Context, graph *topology.Graph, labelErr error) error {
+	name := eng.params.ClusterTopology
+	spec, nodeCount, err := clusterTopologySpec(graph, eng.params)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("failed to encode ClusterTopology %q: %v", name, err)
+	}
+	if len(data) > maxClusterTopologySpecSize {
+		return fmt.Errorf("%w: the spec of %q is %d bytes, above the limit of %d bytes",
+			errClusterTopologyTooLarge, name, len(data), maxClusterTopologySpecSize)
+	}
+
+	res := eng.dynamicClient.Resource(v1alpha1.ClusterTopologyResource)
+	ct := &v1alpha1.ClusterTopology{}
+
+	obj, err := res.Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		ct.APIVersion = v1alpha1.SchemeGroupVersion.String()
+		ct.Kind = "ClusterTopology"
+		ct.Name = name
+		ct.Spec = *spec
+		if obj, err = toUnstructured(ct); err != nil {
+			return err
+		}
+		klog.Infof("Creating ClusterTopology %q", name)
+		if obj, err = res.Create(ctx, obj, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create ClusterTopology %q: %v", name, err)
+		}
+		if err = fromUnstructured(obj, ct); err != nil {
+			return err
+		}
+
+	case err != nil:
+		return fmt.Errorf("failed to get ClusterTopology %q: %v", name, err)
+
+	default:
+		if err = fromUnstructured(obj, ct); err != nil {
+			return err
+		}
+		if !equality.Semantic.DeepEqual(ct.Spec, *spec) {
+			ct.Spec = *spec
+			if obj, err = toUnstructured(ct); err != nil {
+				return err
+			}
+			klog.Infof("Updating ClusterTopology %q", name)
+			if obj, err = res.Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+				return fmt.Errorf("failed to update ClusterTopology %q: %v", name, err)
+			}
+			if err = fromUnstructured(obj, ct); err != nil {
+				return err
+			}
+		}
+	}
+
+	ct.Status.ObservedGeneration = ct.Generation
+	ct.Status.LastGenerated = metav1.Now()
+	ct.Status.TopographVersion = version.Version
+	ct.Status.TierCount = int32(len(spec.Tiers))
+	ct.Status.SwitchCount = int32(len(spec.Switches))
+	ct.Status.AcceleratorDomainCount = int32(len(spec.AcceleratorDomains))
+	ct.Status.NodeCount = int32(nodeCount)
+
+	cond := metav1.Condition{
+		Type:               v1alpha1.ConditionNodesLabeled,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: ct.Generation,
+		Reason:             v1alpha1.ReasonLabelsApplied,
+		Message:            fmt.Sprintf("topology %s applied to the nodes", eng.params.Mode),
+	}
+	if labelErr != nil {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = v1alpha1.ReasonLabelingFailed
+		cond.Message = labelErr.Error()
+	}
+	meta.SetStatusCondition(&ct.Status.Conditions, cond)
+
+	if obj, err = toUnstructured(ct); err != nil {
+		return err
+	}
+	if _, err = res.UpdateStatus(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update ClusterTopology %q status: %v", name, err)
+	}
+	return nil
+}
+
+func toUnstructured(ct *v1alpha1.ClusterTopology) (*unstructured.Unstructured, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert ClusterTopology %q: %v", ct.Name, err)
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+func fromUnstructured(obj *unstructured.Unstructured, ct *v1alpha1.ClusterTopology) error {
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, ct); err != nil {
+		return fmt.Errorf("invalid ClusterTopology %q: %v", obj.GetName(), err)
+	}
+	return nil
+}
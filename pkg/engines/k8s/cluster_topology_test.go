@@ -0,0 +1,170 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/NVIDIA/topograph/pkg/apis/topograph/v1alpha1"
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+func TestClusterTopologySpec(t *testing.T) {
+	tree, _ := translate.GetTreeTestSet(false)
+	spec, nodes, err := clusterTopologySpec(tree, &Params{Labels: DefaultLabelKeys()})
+	require.NoError(t, err)
+	require.Equal(t, 6, nodes)
+	require.Equal(t, &v1alpha1.ClusterTopologySpec{
+		Tiers: []v1alpha1.Tier{
+			{Level: 2, Name: "spine", NodeLabel: DefaultLabelSpine},
+			{Level: 1, Name: "leaf", NodeLabel: DefaultLabelLeaf},
+		},
+		Switches: []v1alpha1.Switch{
+			{Name: "S1", Tier: 2, Children: []string{"S2", "S3"}},
+			{Name: "S2", Tier: 1, Parent: "S1", Nodes: []string{"Node201", "Node202", "Node205"}},
+			{Name: "S3", Tier: 1, Parent: "S1", Nodes: []string{"Node304", "Node305", "Node306"}},
+		},
+	}, spec)
+
+	block, _ := translate.GetBlockWithMultiIBTestSet()
+	p := &Params{Naming: topology.Naming{Switches: "sw{tier}-{index}"}, Labels: DefaultLabelKeys()}
+	spec, nodes, err = clusterTopologySpec(block, p)
+	require.NoError(t, err)
+	require.Equal(t, 12, nodes)
+	require.Equal(t, []v1alpha1.Tier{
+		{Level: 3, Name: "core", NodeLabel: DefaultLabelCore},
+		{Level: 2, Name: "spine", NodeLabel: DefaultLabelSpine},
+		{Level: 1, Name: "leaf", NodeLabel: DefaultLabelLeaf},
+	}, spec.Tiers)
+	require.Len(t, spec.Switches, 8)
+	require.Equal(t, v1alpha1.Switch{
+		Name:     "sw3-1",
+		ID:       "IB1",
+		Tier:     3,
+		Children: []string{"sw2-2"},
+	}, spec.Switches[0])
+	require.Equal(t, v1alpha1.Switch{
+		Name:   "sw1-1",
+		ID:     "S2",
+		Tier:   1,
+		Parent: "sw2-1",
+		Nodes:  []string{"Node104", "Node105", "Node106"},
+	}, spec.Switches[4])
+	require.Equal(t, []v1alpha1.AcceleratorDomain{
+		{Name: "B1", Nodes: []string{"Node104", "Node105", "Node106"}},
+		{Name: "B2", Nodes: []string{"Node201", "Node202", "Node205"}},
+		{Name: "B3", Nodes: []string{"Node301", "Node302", "Node303"}},
+		{Name: "B4", Nodes: []string{"Node401", "Node402", "Node403"}},
+	}, spec.AcceleratorDomains)
+
+	// the tiers refer to no node labels in the annotation mode
+	spec, _, err = clusterTopologySpec(tree, &Params{Labels: DefaultLabelKeys(), Mode: ModeAnnotations})
+	require.NoError(t, err)
+	require.Equal(t, []v1alpha1.Tier{
+		{Level: 2, Name: "spine"},
+		{Level: 1, Name: "leaf"},
+	}, spec.Tiers)
+}
+
+func TestWriteClusterTopology(t *testing.T) {
+	ctx := context.TODO()
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		v1alpha1.ClusterTopologyResource: "ClusterTopologyList",
+	})
+	eng := &K8sEngine{
+		dynamicClient: client,
+		params:        &Params{Labels: DefaultLabelKeys(), Mode: ModeLabels, ClusterTopology: "cluster"},
+	}
+	get := func() *v1alpha1.ClusterTopology {
+		obj, err := client.Resource(v1alpha1.ClusterTopologyResource).Get(ctx, "cluster", metav1.GetOptions{})
+		require.NoError(t, err)
+		ct := &v1alpha1.ClusterTopology{}
+		require.NoError(t, fromUnstructured(obj, ct))
+		return ct
+	}
+
+	tree, _ := translate.GetTreeTestSet(false)
+	require.NoError(t, eng.writeClusterTopology(ctx, tree, nil))
+
+	ct := get()
+	require.Len(t, ct.Spec.Switches, 3)
+	require.Equal(t, int32(2), ct.Status.TierCount)
+	require.Equal(t, int32(3), ct.Status.SwitchCount)
+	require.Equal(t, int32(6), ct.Status.NodeCount)
+	require.False(t, ct.Status.LastGenerated.IsZero())
+	require.Len(t, ct.Status.Conditions, 1)
+	require.Equal(t, v1alpha1.ConditionNodesLabeled, ct.Status.Conditions[0].Type)
+	require.Equal(t, metav1.ConditionTrue, ct.Status.Conditions[0].Status)
+	require.Equal(t, v1alpha1.ReasonLabelsApplied, ct.Status.Conditions[0].Reason)
+
+	block, _ := translate.GetBlockWithMultiIBTestSet()
+	require.NoError(t, eng.writeClusterTopology(ctx, block, fmt.Errorf("nodes \"Node104\" not found")))
+
+	ct = get()
+	require.Len(t, ct.Spec.Switches, 8)
+	require.Len(t, ct.Spec.AcceleratorDomains, 4)
+	require.Equal(t, int32(4), ct.Status.AcceleratorDomainCount)
+	require.Len(t, ct.Status.Conditions, 1)
+	require.Equal(t, metav1.ConditionFalse, ct.Status.Conditions[0].Status)
+	require.Equal(t, v1alpha1.ReasonLabelingFailed, ct.Status.Conditions[0].Reason)
+	require.Equal(t, "nodes \"Node104\" not found", ct.Status.Conditions[0].Message)
+}
+
+func TestWriteClusterTopologyTooLarge(t *testing.T) {
+	ctx := context.TODO()
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		v1alpha1.ClusterTopologyResource: "ClusterTopologyList",
+	})
+	eng := &K8sEngine{
+		dynamicClient: client,
+		params:        &Params{Labels: DefaultLabelKeys(), Mode: ModeLabels, ClusterTopology: "cluster"},
+	}
+
+	domain := make(map[string]*topology.HostInfo)
+	for i := range 100000 {
+		domain[fmt.Sprintf("node%06d", i)] = nil
+	}
+	graph := &topology.Graph{Domains: topology.DomainMap{"nvl1": domain}}
+
+	err := eng.writeClusterTopology(ctx, graph, nil)
+	require.ErrorIs(t, err, errClusterTopologyTooLarge)
+
+	_, err = client.Resource(v1alpha1.ClusterTopologyResource).Get(ctx, "cluster", metav1.GetOptions{})
+	require.Error(t, err)
+}
+
+func TestGenerateOutputClusterTopology(t *testing.T) {
+	ctx := context.TODO()
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		v1alpha1.ClusterTopologyResource: "ClusterTopologyList",
+	})
+	eng := &K8sEngine{
+		client:        kubefake.NewSimpleClientset(),
+		dynamicClient: client,
+		params:        &Params{Labels: DefaultLabelKeys(), Mode: ModeLabels, ClusterTopology: "cluster"},
+	}
+
+	// the nodes of the topology do not exist
+	tree, _ := translate.GetTreeTestSet(false)
+	_, httpErr := eng.GenerateOutput(ctx, tree, nil)
+	require.NotNil(t, httpErr)
+
+	obj, err := client.Resource(v1alpha1.ClusterTopologyResource).Get(ctx, "cluster", metav1.GetOptions{})
+	require.NoError(t, err)
+	ct := &v1alpha1.ClusterTopology{}
+	require.NoError(t, fromUnstructured(obj, ct))
+	require.Equal(t, metav1.ConditionFalse, ct.Status.Conditions[0].Status)
+}
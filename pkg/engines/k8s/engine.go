@@ -18,10 +18,15 @@ package k8s
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -31,17 +36,37 @@ import (
 	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
-const NAME = "k8s"
+const (
+	NAME = "k8s"
+
+	// FieldManager is the field manager of the node label updates
+	FieldManager = "topograph"
+	// DefaultMaxConcurrency is the default number of nodes updated in parallel
+	DefaultMaxConcurrency = 32
+)
 
 type K8sEngine struct {
-	config *rest.Config
-	client *kubernetes.Clientset
-	params *Params
+	config        *rest.Config
+	client        kubernetes.Interface
+	dynamicClient dynamic.Interface
+	params        *Params
 }
 
 type Params struct {
 	// NodeSelector (optional) specifies nodes participating in the topology
 	NodeSelector map[string]string `mapstructure:"nodeSelector"`
+	// Naming (optional) specifies the naming strategy of the switches in the node labels
+	Naming topology.Naming `mapstructure:"naming"`
+	// MaxConcurrency (optional) specifies the number of nodes updated in parallel
+	MaxConcurrency int `mapstructure:"maxConcurrency"`
+	// Labels (optional) specifies the keys of the topology node labels
+	Labels LabelKeys `mapstructure:"labels"`
+	// Mode (optional) specifies whether the topology is written as node labels or annotations
+	Mode string `mapstructure:"mode"`
+	// ClusterTopology (optional) specifies the name of the ClusterTopology object to write
+	ClusterTopology string `mapstructure:"clusterTopology"`
+	// MissingTopology (optional) specifies how the nodes without network topology are marked
+	MissingTopology MissingTopology `mapstructure:"missingTopology"`
 
 	// derived fields
 	nodeListOpt *metav1.ListOptions
@@ -51,8 +76,20 @@ func NamedLoader() (string, engines.Loader) {
 	return NAME, Loader
 }
 
-func Loader(_ context.Context, params engines.Config) (engines.Engine, *httperr.Error) {
-	p, err := getParameters(params)
+func Loader(ctx context.Context, params engines.Config) (engines.Engine, *httperr.Error) {
+	return NewLoader(DefaultLabelKeys())(ctx, params)
+}
+
+// NewLoader returns the loader of the engine with the default keys of the "labels" parameter,
+// e.g., from the command line
+func NewLoader(labelKeys LabelKeys) engines.Loader {
+	return func(ctx context.Context, params engines.Config) (engines.Engine, *httperr.Error) {
+		return load(ctx, params, labelKeys)
+	}
+}
+
+func load(_ context.Context, params engines.Config, labelKeys LabelKeys) (engines.Engine, *httperr.Error) {
+	p, err := getParameters(params, labelKeys)
 	if err != nil {
 		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
 	}
@@ -67,19 +104,58 @@ func Loader(_ context.Context, params engines.Config) (engines.Engine, *httperr.
 		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+	}
+
 	return &K8sEngine{
-		config: config,
-		client: client,
-		params: p,
+		config:        config,
+		client:        client,
+		dynamicClient: dynamicClient,
+		params:        p,
 	}, nil
 }
 
-func getParameters(params engines.Config) (*Params, error) {
+// getParameters decodes the engine parameters; the keys missing from the "labels" parameter
+// are set to the given default keys
+func getParameters(params engines.Config, labelKeys LabelKeys) (*Params, error) {
 	p := &Params{}
 	if err := config.Decode(params, p); err != nil {
 		return nil, err
 	}
 
+	if err := p.Naming.Validate(); err != nil {
+		return nil, err
+	}
+
+	if p.MaxConcurrency < 0 {
+		return nil, fmt.Errorf("maxConcurrency must be non-negative")
+	}
+
+	p.Labels.setDefaults(labelKeys)
+	if err := p.Labels.validate(); err != nil {
+		return nil, err
+	}
+
+	switch p.Mode {
+	case "":
+		p.Mode = ModeLabels
+	case ModeLabels, ModeAnnotations:
+	default:
+		return nil, fmt.Errorf("unsupported mode %q: must be %q or %q", p.Mode, ModeLabels, ModeAnnotations)
+	}
+
+	if len(p.ClusterTopology) != 0 {
+		if errs := validation.IsDNS1123Subdomain(p.ClusterTopology); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid clusterTopology %q: %s", p.ClusterTopology, strings.Join(errs, "; "))
+		}
+	}
+
+	if err := p.MissingTopology.parse(); err != nil {
+		return nil, fmt.Errorf("invalid missingTopology: %v", err)
+	}
+
 	if len(p.NodeSelector) != 0 {
 		p.nodeListOpt = &metav1.ListOptions{
 			LabelSelector: labels.Set(p.NodeSelector).String(),
@@ -89,9 +165,37 @@ func getParameters(params engines.Config) (*Params, error) {
 	return p, nil
 }
 
+// LabelKeys returns the keys of the topology node labels of the request
+func (eng *K8sEngine) LabelKeys() LabelKeys {
+	return eng.params.Labels
+}
+
+// Mode returns whether the topology is written as node labels or annotations
+func (eng *K8sEngine) Mode() string {
+	return eng.params.Mode
+}
+
 func (eng *K8sEngine) GenerateOutput(ctx context.Context, graph *topology.Graph, _ map[string]any) ([]byte, *httperr.Error) {
-	if err := NewTopologyLabeler().ApplyNodeLabels(ctx, graph, eng); err != nil {
-		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+	labelErr := NewTopologyLabeler(eng.params).ApplyNodeLabels(ctx, graph, eng)
+
+	// the ClusterTopology reports the labeling failure in its status
+	if len(eng.params.ClusterTopology) != 0 && graph != nil {
+		if err := eng.writeClusterTopology(ctx, graph, labelErr); err != nil {
+			if errors.Is(err, errClusterTopologyTooLarge) {
+				return nil, httperr.NewError(http.StatusRequestEntityTooLarge, err.Error())
+			}
+			return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+		}
+	}
+
+	if labelErr != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, labelErr.Error())
+	}
+
+	if eng.params.MissingTopology.enabled() && graph != nil {
+		if err := eng.markMissingTopology(ctx, graph); err != nil {
+			return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+		}
 	}
 
 	return []byte("OK\n"), nil
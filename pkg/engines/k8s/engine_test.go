@@ -9,7 +9,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
 func TestGetParameters(t *testing.T) {
@@ -22,7 +25,7 @@ func TestGetParameters(t *testing.T) {
 		{
 			name:   "Case 1: no params",
 			params: nil,
-			ret:    &Params{},
+			ret:    &Params{Labels: DefaultLabelKeys(), Mode: ModeLabels},
 		},
 		{
 			name:   "Case 2: bad params",
@@ -34,16 +37,93 @@ func TestGetParameters(t *testing.T) {
 			params: map[string]any{"nodeSelector": map[string]string{"key": "val"}},
 			ret: &Params{
 				NodeSelector: map[string]string{"key": "val"},
+				Labels:       DefaultLabelKeys(),
+				Mode:         ModeLabels,
 				nodeListOpt: &metav1.ListOptions{
 					LabelSelector: "key=val",
 				},
 			},
 		},
+		{
+			name:   "Case 4: switch naming",
+			params: map[string]any{"naming": map[string]any{"switches": "hash"}},
+			ret:    &Params{Naming: topology.Naming{Switches: topology.NamingHash}, Labels: DefaultLabelKeys(), Mode: ModeLabels},
+		},
+		{
+			name:   "Case 5: invalid switch naming",
+			params: map[string]any{"naming": map[string]any{"switches": "{rack}"}},
+			err:    "invalid switch naming: unsupported placeholder {rack} in template \"{rack}\"",
+		},
+		{
+			name:   "Case 6: max concurrency",
+			params: map[string]any{"maxConcurrency": 8},
+			ret:    &Params{MaxConcurrency: 8, Labels: DefaultLabelKeys(), Mode: ModeLabels},
+		},
+		{
+			name:   "Case 7: invalid max concurrency",
+			params: map[string]any{"maxConcurrency": -1},
+			err:    "maxConcurrency must be non-negative",
+		},
+		{
+			name: "Case 8: label keys and annotations",
+			params: map[string]any{
+				"labels": map[string]any{"leaf": "cloud.provider.com/topology-block", "leafPosition": "cloud.provider.com/topology-position"},
+				"mode":   "annotations",
+			},
+			ret: &Params{
+				Labels: LabelKeys{
+					Accelerator:  DefaultLabelAccelerator,
+					Leaf:         "cloud.provider.com/topology-block",
+					Spine:        DefaultLabelSpine,
+					Core:         DefaultLabelCore,
+					LeafPosition: "cloud.provider.com/topology-position",
+				},
+				Mode: ModeAnnotations,
+			},
+		},
+		{
+			name:   "Case 9: duplicate label keys",
+			params: map[string]any{"labels": map[string]any{"spine": DefaultLabelLeaf}},
+			err:    `duplicate label key "network.topology.nvidia.com/leaf"`,
+		},
+		{
+			name:   "Case 10: unsupported mode",
+			params: map[string]any{"mode": "taints"},
+			err:    `unsupported mode "taints": must be "labels" or "annotations"`,
+		},
+		{
+			name:   "Case 11: cluster topology",
+			params: map[string]any{"clusterTopology": "cluster"},
+			ret:    &Params{Labels: DefaultLabelKeys(), Mode: ModeLabels, ClusterTopology: "cluster"},
+		},
+		{
+			name:   "Case 12: invalid cluster topology name",
+			params: map[string]any{"clusterTopology": "Cluster_1"},
+			err:    `invalid clusterTopology "Cluster_1"`,
+		},
+		{
+			name:   "Case 13: missing topology marks",
+			params: map[string]any{"missingTopology": map[string]any{"taint": "no-topology:NoSchedule", "condition": "TopologyMissing"}},
+			ret: &Params{
+				Labels: DefaultLabelKeys(),
+				Mode:   ModeLabels,
+				MissingTopology: MissingTopology{
+					Taint:     "no-topology:NoSchedule",
+					Condition: "TopologyMissing",
+					taint:     &corev1.Taint{Key: "no-topology", Effect: corev1.TaintEffectNoSchedule},
+				},
+			},
+		},
+		{
+			name:   "Case 14: invalid missing topology taint",
+			params: map[string]any{"missingTopology": map[string]any{"taint": "no-topology=true"}},
+			err:    `invalid missingTopology: invalid taint "no-topology=true"`,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			p, err := getParameters(tc.params)
+			p, err := getParameters(tc.params, DefaultLabelKeys())
 			if len(tc.err) != 0 {
 				require.ErrorContains(t, err, tc.err)
 			} else {
@@ -18,12 +18,17 @@ package k8s
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
 	"maps"
 	"net/http"
 	"strings"
 
+	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 
 	"github.com/NVIDIA/topograph/internal/httperr"
@@ -68,30 +73,169 @@ func getComputeInstances(nodes *corev1.NodeList) []topology.ComputeInstances {
 	return cis
 }
 
-func (eng *K8sEngine) AddNodeLabels(ctx context.Context, nodeName string, labels map[string]string) error {
-	klog.Infof("Applying labels on node %s : %v", nodeName, labels)
-	node, err := eng.client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+// UpdateNodeLabels sets the topology labels or annotations of the nodes to the desired ones,
+// and removes the stale ones from the nodes matched by the node selector,
+// including the nodes absent from the desired set
+func (eng *K8sEngine) UpdateNodeLabels(ctx context.Context, nodeLabels map[string]map[string]string) error {
+	nodes, err := eng.getNodes(ctx, maps.Keys(nodeLabels))
 	if err != nil {
 		return err
 	}
 
-	MergeNodeLabels(node, labels)
+	g, ctx := eng.newNodeGroup(ctx)
 
-	_, err = eng.client.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+	for _, node := range nodes {
+		labels, ok := nodeLabels[node.Name]
+		// without a node selector, the nodes absent from the topology are only cleared
+		// if Topograph wrote their topology keys, so that a partial topology does not strip
+		// the keys set by other tools across the cluster
+		if !ok && eng.params.nodeListOpt == nil && !ownsTopologyKeys(node, eng.params.Labels.managed()) {
+			continue
+		}
+		patch := nodeLabelPatch(node, labels, eng.params)
+		if patch == nil {
+			continue
+		}
+		g.Go(func() error {
+			return eng.patchNodeLabels(ctx, node.Name, patch)
+		})
+	}
+
+	return g.Wait()
+}
+
+// getNodes returns the nodes matched by the node selector and the given nodes, keyed by node name
+func (eng *K8sEngine) getNodes(ctx context.Context, nodeNames iter.Seq[string]) (map[string]*corev1.Node, error) {
+	nodeList, err := k8s.GetNodes(ctx, eng.client, eng.params.nodeListOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]*corev1.Node, len(nodeList.Items))
+	for i := range nodeList.Items {
+		nodes[nodeList.Items[i].Name] = &nodeList.Items[i]
+	}
+	for nodeName := range nodeNames {
+		if _, ok := nodes[nodeName]; ok {
+			continue
+		}
+		// the node is in the topology but not matched by the node selector
+		node, err := eng.client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		nodes[nodeName] = node
+	}
+	return nodes, nil
+}
+
+// ownsTopologyKeys reports whether the Topograph field manager wrote any of the topology keys
+// in the node labels or annotations
+func ownsTopologyKeys(node *corev1.Node, keys []string) bool {
+	for _, entry := range node.ManagedFields {
+		if entry.Manager != FieldManager || entry.FieldsV1 == nil {
+			continue
+		}
+		var fields struct {
+			Metadata struct {
+				Labels      map[string]any `json:"f:labels"`
+				Annotations map[string]any `json:"f:annotations"`
+			} `json:"f:metadata"`
+		}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			klog.Warningf("Failed to parse managed fields of node %s: %v", node.Name, err)
+			continue
+		}
+		for _, key := range keys {
+			if _, ok := fields.Metadata.Labels["f:"+key]; ok {
+				return true
+			}
+			if _, ok := fields.Metadata.Annotations["f:"+key]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
 
-	return err
+// newNodeGroup returns the group of the node updates running in parallel up to the concurrency limit
+func (eng *K8sEngine) newNodeGroup(ctx context.Context) (*errgroup.Group, context.Context) {
+	limit := eng.params.MaxConcurrency
+	if limit <= 0 {
+		limit = DefaultMaxConcurrency
+	}
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(limit)
+	return g, ctx
 }
 
-func MergeNodeLabels(node *corev1.Node, labels map[string]string) {
-	if node.Labels == nil {
-		node.Labels = make(map[string]string)
+func (eng *K8sEngine) patchNodeLabels(ctx context.Context, nodeName string, metadata map[string]any) error {
+	klog.Infof("Updating %s on node %s : %v", eng.params.Mode, nodeName, metadata)
+	data, err := json.Marshal(map[string]any{"metadata": metadata})
+	if err != nil {
+		return err
 	}
 
-	labels = skipAcceleratorLabelWhenGPUCliqueExists(node, labels)
-	maps.Copy(node.Labels, labels)
+	_, err = eng.client.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, data,
+		metav1.PatchOptions{FieldManager: FieldManager})
+	if err != nil {
+		return fmt.Errorf("failed to update %s on node %s: %v", eng.params.Mode, nodeName, err)
+	}
+	return nil
 }
 
-func skipAcceleratorLabelWhenGPUCliqueExists(node *corev1.Node, labels map[string]string) map[string]string {
+// nodeLabelPatch returns the merge patch of the node metadata, or nil if the node is up to date.
+// The patch maps the topology keys to set to their values and the stale topology keys to nil
+// in the labels, or in the annotations in the annotation mode. The topology keys are also removed
+// from the annotations in the label mode, and from the labels in the annotation mode, so that
+// no stale keys are left behind when the mode changes.
+func nodeLabelPatch(node *corev1.Node, labels map[string]string, p *Params) map[string]any {
+	labels = skipAcceleratorLabelWhenGPUCliqueExists(node, labels, p.Labels.Accelerator)
+
+	current, other := node.Labels, node.Annotations
+	otherMode := ModeAnnotations
+	if p.Mode == ModeAnnotations {
+		current, other = node.Annotations, node.Labels
+		otherMode = ModeLabels
+	}
+
+	patch := make(map[string]any)
+	otherPatch := make(map[string]any)
+	for key, val := range labels {
+		if cur, ok := current[key]; !ok || cur != val {
+			patch[key] = val
+		}
+	}
+	for _, key := range p.Labels.managed() {
+		// the GPU clique label is owned by the GPU operator
+		if key == topology.KeyNvidiaGPUClique {
+			continue
+		}
+		if _, ok := other[key]; ok {
+			otherPatch[key] = nil
+		}
+		if _, ok := labels[key]; ok {
+			continue
+		}
+		if _, ok := current[key]; ok {
+			patch[key] = nil
+		}
+	}
+
+	metadata := make(map[string]any)
+	if len(patch) != 0 {
+		metadata[p.Mode] = patch
+	}
+	if len(otherPatch) != 0 {
+		metadata[otherMode] = otherPatch
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+func skipAcceleratorLabelWhenGPUCliqueExists(node *corev1.Node, labels map[string]string, labelAccelerator string) map[string]string {
 	if labelAccelerator == "" || strings.TrimSpace(node.Labels[topology.KeyNvidiaGPUClique]) == "" {
 		return labels
 	}
@@ -99,9 +243,5 @@ func skipAcceleratorLabelWhenGPUCliqueExists(node *corev1.Node, labels map[strin
 	filtered := maps.Clone(labels)
 	delete(filtered, labelAccelerator)
 
-	if labelAccelerator != topology.KeyNvidiaGPUClique {
-		delete(node.Labels, labelAccelerator)
-	}
-
 	return filtered
 }
@@ -6,12 +6,15 @@
 package k8s
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/NVIDIA/topograph/pkg/topology"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestGetComputeInstances(t *testing.T) {
@@ -70,40 +73,63 @@ func TestGetComputeInstances(t *testing.T) {
 	}
 }
 
-func TestMergeNodeLabels(t *testing.T) {
-	InitLabels(DefaultLabelAccelerator, DefaultLabelLeaf, DefaultLabelSpine, DefaultLabelCore)
-
+func TestNodeLabelPatch(t *testing.T) {
 	testCases := []struct {
 		name             string
 		acceleratorLabel string
+		mode             string
+		blockIndex       string
 		node             *corev1.Node
 		in               map[string]string
-		out              map[string]string
+		patch            map[string]any
+		otherPatch       map[string]any
 	}{
 		{
 			name: "Case 1: no labels",
 			node: &corev1.Node{},
-			out:  map[string]string{},
 		},
 		{
-			name: "Case 2: copy",
-			node: &corev1.Node{},
-			in:   map[string]string{"a": "1", "b": "2"},
-			out:  map[string]string{"a": "1", "b": "2"},
+			name:  "Case 2: set labels",
+			node:  &corev1.Node{},
+			in:    map[string]string{DefaultLabelLeaf: "s1", DefaultLabelSpine: "s2"},
+			patch: map[string]any{DefaultLabelLeaf: "s1", DefaultLabelSpine: "s2"},
+		},
+		{
+			name: "Case 3: up to date",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{DefaultLabelLeaf: "s1", "a": "1"},
+				},
+			},
+			in: map[string]string{DefaultLabelLeaf: "s1"},
+		},
+		{
+			name: "Case 4: update and remove stale labels",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						DefaultLabelAccelerator: "b1",
+						DefaultLabelLeaf:        "s1",
+						DefaultLabelSpine:       "s2",
+						DefaultLabelCore:        "s3",
+						"a":                     "1",
+					},
+				},
+			},
+			in:    map[string]string{DefaultLabelLeaf: "s4", DefaultLabelSpine: "s2"},
+			patch: map[string]any{DefaultLabelAccelerator: nil, DefaultLabelLeaf: "s4", DefaultLabelCore: nil},
 		},
 		{
-			name: "Case 3: merge",
+			name: "Case 5: remove labels from node absent from topology",
 			node: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      map[string]string{"a": "1", "b": "2", "c": "x"},
-					Annotations: map[string]string{"a": "1", "b": "2", "c": "x"},
+					Labels: map[string]string{DefaultLabelLeaf: "s1", "a": "1"},
 				},
 			},
-			in:  map[string]string{"c": "3", "d": "4"},
-			out: map[string]string{"a": "1", "b": "2", "c": "3", "d": "4"},
+			patch: map[string]any{DefaultLabelLeaf: nil},
 		},
 		{
-			name: "Case 4: skip accelerator when GPU clique exists",
+			name: "Case 6: skip accelerator when GPU clique exists",
 			node: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
@@ -119,15 +145,14 @@ func TestMergeNodeLabels(t *testing.T) {
 				DefaultLabelLeaf:        "new-leaf",
 				DefaultLabelSpine:       "new-spine",
 			},
-			out: map[string]string{
-				topology.KeyNvidiaGPUClique: "cluster-a.0",
-				DefaultLabelLeaf:            "new-leaf",
-				DefaultLabelSpine:           "new-spine",
-				"workload.example/label":    "keep",
+			patch: map[string]any{
+				DefaultLabelAccelerator: nil,
+				DefaultLabelLeaf:        "new-leaf",
+				DefaultLabelSpine:       "new-spine",
 			},
 		},
 		{
-			name:             "Case 5: do not overwrite GPU clique when it is the configured accelerator label",
+			name:             "Case 7: do not touch GPU clique when it is the configured accelerator label",
 			acceleratorLabel: topology.KeyNvidiaGPUClique,
 			node: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
@@ -141,22 +166,170 @@ func TestMergeNodeLabels(t *testing.T) {
 				DefaultLabelLeaf:            "new-leaf",
 				DefaultLabelSpine:           "new-spine",
 			},
-			out: map[string]string{
-				topology.KeyNvidiaGPUClique: "cluster-a.0",
-				DefaultLabelLeaf:            "new-leaf",
-				DefaultLabelSpine:           "new-spine",
+			patch: map[string]any{
+				DefaultLabelLeaf:  "new-leaf",
+				DefaultLabelSpine: "new-spine",
+			},
+		},
+		{
+			name: "Case 8: annotations",
+			mode: ModeAnnotations,
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{DefaultLabelLeaf: "s1"},
+					Annotations: map[string]string{DefaultLabelLeaf: "s1", DefaultLabelSpine: "s2"},
+				},
 			},
+			in:         map[string]string{DefaultLabelLeaf: "s1", DefaultLabelCore: "s3"},
+			patch:      map[string]any{DefaultLabelSpine: nil, DefaultLabelCore: "s3"},
+			otherPatch: map[string]any{DefaultLabelLeaf: nil},
+		},
+		{
+			name:       "Case 9: extra labels",
+			blockIndex: "example.com/block",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"example.com/block": "2", "example.com/position": "1"},
+				},
+			},
+			in:    map[string]string{DefaultLabelAccelerator: "b1", "example.com/block": "1"},
+			patch: map[string]any{DefaultLabelAccelerator: "b1", "example.com/block": "1"},
+		},
+		{
+			name: "Case 10: remove annotations left by the annotation mode",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{DefaultLabelLeaf: "s1"},
+					Annotations: map[string]string{DefaultLabelLeaf: "s1", "a": "1"},
+				},
+			},
+			in:         map[string]string{DefaultLabelLeaf: "s1"},
+			otherPatch: map[string]any{DefaultLabelLeaf: nil},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			p := &Params{Labels: DefaultLabelKeys(), Mode: ModeLabels}
 			if tc.acceleratorLabel != "" {
-				InitLabels(tc.acceleratorLabel, DefaultLabelLeaf, DefaultLabelSpine, DefaultLabelCore)
-				defer InitLabels(DefaultLabelAccelerator, DefaultLabelLeaf, DefaultLabelSpine, DefaultLabelCore)
+				p.Labels.Accelerator = tc.acceleratorLabel
 			}
-			MergeNodeLabels(tc.node, tc.in)
-			require.Equal(t, tc.out, tc.node.Labels)
+			if tc.mode != "" {
+				p.Mode = tc.mode
+			}
+			p.Labels.BlockIndex = tc.blockIndex
+
+			var expected map[string]any
+			if tc.patch != nil || tc.otherPatch != nil {
+				expected = make(map[string]any)
+				otherMode := ModeAnnotations
+				if p.Mode == ModeAnnotations {
+					otherMode = ModeLabels
+				}
+				if tc.patch != nil {
+					expected[p.Mode] = tc.patch
+				}
+				if tc.otherPatch != nil {
+					expected[otherMode] = tc.otherPatch
+				}
+			}
+			require.Equal(t, expected, nodeLabelPatch(tc.node, tc.in, p))
 		})
 	}
 }
+
+func TestUpdateNodeLabels(t *testing.T) {
+	newNode := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	client := fake.NewSimpleClientset(
+		newNode("n1", map[string]string{"pool": "gpu", DefaultLabelLeaf: "s1", DefaultLabelSpine: "s9"}),
+		newNode("n2", map[string]string{"pool": "gpu", DefaultLabelLeaf: "s1", DefaultLabelAccelerator: "b1"}),
+		newNode("n3", map[string]string{"pool": "cpu", DefaultLabelLeaf: "s1"}),
+		newNode("n4", map[string]string{"pool": "cpu"}),
+	)
+	eng := &K8sEngine{
+		client: client,
+		params: &Params{
+			MaxConcurrency: 2,
+			Labels:         DefaultLabelKeys(),
+			Mode:           ModeLabels,
+			nodeListOpt:    &metav1.ListOptions{LabelSelector: "pool=gpu"},
+		},
+	}
+
+	err := eng.UpdateNodeLabels(context.TODO(), map[string]map[string]string{
+		"n1": {DefaultLabelLeaf: "s2"},
+		"n4": {DefaultLabelLeaf: "s3"},
+	})
+	require.NoError(t, err)
+
+	expected := map[string]map[string]string{
+		// stale spine label removed
+		"n1": {"pool": "gpu", DefaultLabelLeaf: "s2"},
+		// absent from the topology
+		"n2": {"pool": "gpu"},
+		// not matched by the node selector
+		"n3": {"pool": "cpu", DefaultLabelLeaf: "s1"},
+		// in the topology but not matched by the node selector
+		"n4": {"pool": "cpu", DefaultLabelLeaf: "s3"},
+	}
+	for name, labels := range expected {
+		node, err := client.CoreV1().Nodes().Get(context.TODO(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, labels, node.Labels, name)
+	}
+
+	// an empty topology removes the labels from the nodes matched by the node selector
+	require.NoError(t, NewTopologyLabeler(eng.params).ApplyNodeLabels(context.TODO(), &topology.Graph{}, eng))
+	node, err := client.CoreV1().Nodes().Get(context.TODO(), "n1", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"pool": "gpu"}, node.Labels)
+
+	err = eng.UpdateNodeLabels(context.TODO(), map[string]map[string]string{"n5": {DefaultLabelLeaf: "s1"}})
+	require.ErrorContains(t, err, `nodes "n5" not found`)
+}
+
+func TestUpdateNodeLabelsWithoutNodeSelector(t *testing.T) {
+	managedFields := func(manager string, keys ...string) []metav1.ManagedFieldsEntry {
+		labels := make(map[string]any)
+		for _, key := range keys {
+			labels["f:"+key] = map[string]any{}
+		}
+		raw, err := json.Marshal(map[string]any{"f:metadata": map[string]any{"f:labels": labels}})
+		require.NoError(t, err)
+		return []metav1.ManagedFieldsEntry{{Manager: manager, Operation: metav1.ManagedFieldsOperationUpdate, FieldsV1: &metav1.FieldsV1{Raw: raw}}}
+	}
+	newNode := func(name, manager string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:          name,
+			Labels:        map[string]string{"pool": "gpu", DefaultLabelLeaf: "s1"},
+			ManagedFields: managedFields(manager, DefaultLabelLeaf),
+		}}
+	}
+	client := fake.NewSimpleClientset(
+		newNode("n1", FieldManager),
+		// labeled by Topograph, absent from the topology
+		newNode("n2", FieldManager),
+		// labeled by another tool
+		newNode("n3", "cloud-controller"),
+	)
+	eng := &K8sEngine{
+		client: client,
+		params: &Params{Labels: DefaultLabelKeys(), Mode: ModeLabels},
+	}
+
+	err := eng.UpdateNodeLabels(context.TODO(), map[string]map[string]string{"n1": {DefaultLabelLeaf: "s2"}})
+	require.NoError(t, err)
+
+	expected := map[string]map[string]string{
+		"n1": {"pool": "gpu", DefaultLabelLeaf: "s2"},
+		"n2": {"pool": "gpu"},
+		"n3": {"pool": "gpu", DefaultLabelLeaf: "s1"},
+	}
+	for name, labels := range expected {
+		node, err := client.CoreV1().Nodes().Get(context.TODO(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, labels, node.Labels, name)
+	}
+}
@@ -20,6 +20,12 @@ import (
 	"context"
 	"fmt"
 	"hash/fnv"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/NVIDIA/topograph/pkg/topology"
 )
@@ -29,44 +35,131 @@ const (
 	DefaultLabelLeaf        = "network.topology.nvidia.com/leaf"
 	DefaultLabelSpine       = "network.topology.nvidia.com/spine"
 	DefaultLabelCore        = "network.topology.nvidia.com/core"
+
+	// ModeLabels writes the topology as node labels
+	ModeLabels = "labels"
+	// ModeAnnotations writes the topology as node annotations
+	ModeAnnotations = "annotations"
 )
 
-var (
-	labelAccelerator, labelLeaf, labelSpine, labelCore string
+// LabelKeys specifies the keys of the topology node labels
+type LabelKeys struct {
+	// Accelerator is the key of the accelerator domain
+	Accelerator string `mapstructure:"accelerator"`
+	// Leaf, Spine and Core are the keys of the switches from the lowest network tier up
+	Leaf  string `mapstructure:"leaf"`
+	Spine string `mapstructure:"spine"`
+	Core  string `mapstructure:"core"`
+	// BlockIndex (optional) is the key of the 1-based index of the accelerator domain
+	// among the domains ordered by name
+	BlockIndex string `mapstructure:"blockIndex"`
+	// LeafPosition (optional) is the key of the 1-based position of the node
+	// among the nodes of its leaf switch ordered by name
+	LeafPosition string `mapstructure:"leafPosition"`
+}
 
-	switchNetworkHierarchy []string
-)
+func DefaultLabelKeys() LabelKeys {
+	return LabelKeys{
+		Accelerator: DefaultLabelAccelerator,
+		Leaf:        DefaultLabelLeaf,
+		Spine:       DefaultLabelSpine,
+		Core:        DefaultLabelCore,
+	}
+}
+
+// NewLabelKeys returns the keys of the accelerator and switch tiers, e.g., from the command line,
+// to use as the defaults of the engine "labels" parameter; the empty keys keep their default keys
+func NewLabelKeys(accelerator, leaf, spine, core string) (LabelKeys, error) {
+	keys := LabelKeys{Accelerator: accelerator, Leaf: leaf, Spine: spine, Core: core}
+	keys.setDefaults(DefaultLabelKeys())
+	if err := keys.validate(); err != nil {
+		return LabelKeys{}, err
+	}
+	return keys, nil
+}
+
+// setDefaults sets the missing keys of the accelerator and switch tiers to the default keys
+func (k *LabelKeys) setDefaults(defaults LabelKeys) {
+	for _, pair := range [][2]*string{
+		{&k.Accelerator, &defaults.Accelerator},
+		{&k.Leaf, &defaults.Leaf},
+		{&k.Spine, &defaults.Spine},
+		{&k.Core, &defaults.Core},
+	} {
+		if len(*pair[0]) == 0 {
+			*pair[0] = *pair[1]
+		}
+	}
+}
+
+// validate checks that the keys are valid and distinct
+func (k *LabelKeys) validate() error {
+	keys := make(map[string]bool)
+	for _, key := range k.managed() {
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if keys[key] {
+			return fmt.Errorf("duplicate label key %q", key)
+		}
+		keys[key] = true
+	}
+	return nil
+}
+
+// tiers returns the keys of the switch tiers from the lowest tier up
+func (k *LabelKeys) tiers() []string {
+	return []string{k.Leaf, k.Spine, k.Core}
+}
 
-func InitLabels(accelerator, leaf, spine, core string) {
-	labelAccelerator = accelerator
-	labelLeaf = leaf
-	labelSpine = spine
-	labelCore = core
-	switchNetworkHierarchy = []string{labelLeaf, labelSpine, labelCore}
+// managed returns the keys owned by the topology labeler
+func (k *LabelKeys) managed() []string {
+	keys := append([]string{k.Accelerator}, k.tiers()...)
+	for _, key := range []string{k.BlockIndex, k.LeafPosition} {
+		if len(key) != 0 {
+			keys = append(keys, key)
+		}
+	}
+	return keys
 }
 
 // map nodename:[label name: label value]
 type nodeLabelMap map[string]map[string]string
 
 type Labeler interface {
-	AddNodeLabels(context.Context, string, map[string]string) error
+	// UpdateNodeLabels sets the topology labels or annotations of the nodes, keyed by node name,
+	// and removes the ones no longer desired
+	UpdateNodeLabels(context.Context, map[string]map[string]string) error
 }
 
 type topologyLabeler struct {
 	mapper map[string]string
+	naming topology.Naming
+	keys   LabelKeys
+	// annotations are not limited to 63 characters
+	annotate bool
+	// switch ID to name map under the switch naming strategy
+	switchNames map[string]string
+	// node name to position within its leaf switch
+	positions map[string]int
 }
 
-func NewTopologyLabeler() *topologyLabeler {
+func NewTopologyLabeler(p *Params) *topologyLabeler {
 	return &topologyLabeler{
-		mapper: make(map[string]string),
+		mapper:    make(map[string]string),
+		naming:    p.Naming,
+		keys:      p.Labels,
+		annotate:  p.Mode == ModeAnnotations,
+		positions: make(map[string]int),
 	}
 }
 
 func (l *topologyLabeler) ApplyNodeLabels(ctx context.Context, graph *topology.Graph, labeler Labeler) error {
-	if graph == nil || (graph.Domains == nil && graph.Tiers == nil) {
+	if graph == nil {
 		return nil
 	}
 
+	// an empty topology removes the topology labels from the nodes
 	nodeMap := make(nodeLabelMap)
 	if graph.Domains != nil {
 		if err := l.getDomainLabels(graph.Domains, nodeMap); err != nil {
@@ -74,7 +167,14 @@ func (l *topologyLabeler) ApplyNodeLabels(ctx context.Context, graph *topology.G
 		}
 	}
 
-	if treeRoot := graph.Tiers; treeRoot != nil {
+	// node labels carry a single switch per tier
+	if treeRoot := graph.ToTree().Tiers; treeRoot != nil {
+		names, err := l.naming.SwitchNames(treeRoot)
+		if err != nil {
+			return err
+		}
+		l.switchNames = names
+
 		layers := []string{}
 		if len(treeRoot.ID) != 0 {
 			layers = append(layers, treeRoot.ID)
@@ -84,27 +184,24 @@ func (l *topologyLabeler) ApplyNodeLabels(ctx context.Context, graph *topology.G
 		}
 	}
 
-	for nodeName, labels := range nodeMap {
-		if err := labeler.AddNodeLabels(ctx, nodeName, labels); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return labeler.UpdateNodeLabels(ctx, nodeMap)
 }
 
 func (l *topologyLabeler) getDomainLabels(domains topology.DomainMap, nodeMap nodeLabelMap) error {
-	for domainName, domain := range domains {
-		for nodeName := range domain {
+	for i, domainName := range slices.Sorted(maps.Keys(domains)) {
+		for nodeName := range domains[domainName] {
 			labels, ok := nodeMap[nodeName]
 			if !ok {
 				labels = make(map[string]string)
 				nodeMap[nodeName] = labels
 			}
-			if val, ok := labels[labelAccelerator]; ok {
+			if val, ok := labels[l.keys.Accelerator]; ok {
 				return fmt.Errorf("multiple accelerator labels %s, %s for node %s", val, domainName, nodeName)
 			}
-			labels[labelAccelerator] = l.checkLabel(domainName)
+			labels[l.keys.Accelerator] = l.checkLabel(domainName)
+			if len(l.keys.BlockIndex) != 0 {
+				labels[l.keys.BlockIndex] = strconv.Itoa(i + 1)
+			}
 		}
 	}
 	return nil
@@ -122,18 +219,36 @@ func (l *topologyLabeler) getTierLabels(v *topology.Vertex, nodeMap nodeLabelMap
 				labels = make(map[string]string)
 				nodeMap[nodeName] = labels
 			}
+			tiers := l.keys.tiers()
 			for i, sw := range layers[1:] {
 				if len(sw) == 0 {
 					break
 				}
-				if i < len(switchNetworkHierarchy) {
-					labels[(switchNetworkHierarchy[i])] = l.checkLabel(sw)
+				if i < len(tiers) {
+					if name, ok := l.switchNames[sw]; ok {
+						sw = name
+					}
+					labels[tiers[i]] = l.checkLabel(sw)
 				}
 			}
+			if pos, ok := l.positions[nodeName]; ok && len(l.keys.LeafPosition) != 0 {
+				labels[l.keys.LeafPosition] = strconv.Itoa(pos)
+			}
 		}
 		return nil
 	}
 
+	nodeNames := []string{}
+	for _, w := range v.Vertices {
+		if len(w.Vertices) == 0 {
+			nodeNames = append(nodeNames, w.Name)
+		}
+	}
+	slices.Sort(nodeNames)
+	for i, nodeName := range nodeNames {
+		l.positions[nodeName] = i + 1
+	}
+
 	for _, w := range v.Vertices {
 		if err := l.getTierLabels(w, nodeMap, append([]string{w.ID}, layers...)); err != nil {
 			return err
@@ -143,9 +258,52 @@ func (l *topologyLabeler) getTierLabels(v *topology.Vertex, nodeMap nodeLabelMap
 	return nil
 }
 
+// TopologyLevels returns the keys of the node labels assigned for the topology graph,
+// ordered from the top switch tier down to the accelerator domains
+func TopologyLevels(graph *topology.Graph, keys LabelKeys) []string {
+	levels := []string{}
+	if graph == nil {
+		return levels
+	}
+
+	if treeRoot := graph.ToTree().Tiers; treeRoot != nil {
+		tiers := treeDepth(treeRoot)
+		if len(treeRoot.ID) == 0 {
+			tiers--
+		}
+		hierarchy := keys.tiers()
+		tiers = min(tiers, len(hierarchy))
+		for i := tiers - 1; i >= 0; i-- {
+			levels = append(levels, hierarchy[i])
+		}
+	}
+
+	if len(graph.Domains) != 0 {
+		levels = append(levels, keys.Accelerator)
+	}
+
+	return levels
+}
+
+// treeDepth returns the number of switch tiers from the vertex down to the compute nodes
+func treeDepth(v *topology.Vertex) int {
+	if len(v.Vertices) == 0 {
+		return 0
+	}
+	depth := 0
+	for _, w := range v.Vertices {
+		depth = max(depth, treeDepth(w))
+	}
+	return depth + 1
+}
+
 // checkLabel checks the length of the label value.
 // If more than 63 characters (Kubernetes limit), it will replace it with hash
 func (l *topologyLabeler) checkLabel(val string) string {
+	if l.annotate {
+		return val
+	}
+
 	v, ok := l.mapper[val]
 	if ok {
 		return v
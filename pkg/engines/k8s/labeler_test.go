@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/NVIDIA/topograph/pkg/topology"
 	"github.com/NVIDIA/topograph/pkg/translate"
 )
 
@@ -30,18 +31,17 @@ type testLabeler struct {
 	data map[string]map[string]string
 }
 
-func (l *testLabeler) AddNodeLabels(_ context.Context, nodeName string, labels map[string]string) error {
-	if _, ok := l.data[nodeName]; ok {
-		return fmt.Errorf("duplicate entry for %s", nodeName)
+func (l *testLabeler) UpdateNodeLabels(_ context.Context, nodeLabels map[string]map[string]string) error {
+	if l.data != nil {
+		return fmt.Errorf("duplicate update")
 	}
-	l.data[nodeName] = labels
+	l.data = nodeLabels
 	return nil
 }
 
 func TestApplyNodeLabelsWithTree(t *testing.T) {
-	InitLabels(DefaultLabelAccelerator, DefaultLabelLeaf, DefaultLabelSpine, DefaultLabelCore)
 	root, _ := translate.GetTreeTestSet(true)
-	labeler := &testLabeler{data: make(map[string]map[string]string)}
+	labeler := &testLabeler{}
 	data := map[string]map[string]string{
 		"Node201": {"network.topology.nvidia.com/leaf": "S2", "network.topology.nvidia.com/spine": "S1"},
 		"Node202": {"network.topology.nvidia.com/leaf": "S2", "network.topology.nvidia.com/spine": "S1"},
@@ -51,15 +51,31 @@ func TestApplyNodeLabelsWithTree(t *testing.T) {
 		"Node306": {"network.topology.nvidia.com/leaf": "xf946c4acef2d5939", "network.topology.nvidia.com/spine": "S1"},
 	}
 
-	err := NewTopologyLabeler().ApplyNodeLabels(context.TODO(), root, labeler)
+	err := NewTopologyLabeler(&Params{Labels: DefaultLabelKeys()}).ApplyNodeLabels(context.TODO(), root, labeler)
+	require.NoError(t, err)
+	require.Equal(t, data, labeler.data)
+}
+
+func TestApplyNodeLabelsWithNaming(t *testing.T) {
+	root, _ := translate.GetTreeTestSet(true)
+	labeler := &testLabeler{}
+	data := map[string]map[string]string{
+		"Node201": {"network.topology.nvidia.com/leaf": "sw1-1", "network.topology.nvidia.com/spine": "sw2-1"},
+		"Node202": {"network.topology.nvidia.com/leaf": "sw1-1", "network.topology.nvidia.com/spine": "sw2-1"},
+		"Node205": {"network.topology.nvidia.com/leaf": "sw1-1", "network.topology.nvidia.com/spine": "sw2-1"},
+		"Node304": {"network.topology.nvidia.com/leaf": "sw1-2", "network.topology.nvidia.com/spine": "sw2-1"},
+		"Node305": {"network.topology.nvidia.com/leaf": "sw1-2", "network.topology.nvidia.com/spine": "sw2-1"},
+		"Node306": {"network.topology.nvidia.com/leaf": "sw1-2", "network.topology.nvidia.com/spine": "sw2-1"},
+	}
+
+	err := NewTopologyLabeler(&Params{Naming: topology.Naming{Switches: "sw{tier}-{index}"}, Labels: DefaultLabelKeys()}).ApplyNodeLabels(context.TODO(), root, labeler)
 	require.NoError(t, err)
 	require.Equal(t, data, labeler.data)
 }
 
 func TestApplyNodeLabelsWithBlock(t *testing.T) {
-	InitLabels(DefaultLabelAccelerator, DefaultLabelLeaf, DefaultLabelSpine, DefaultLabelCore)
 	root, _ := translate.GetBlockWithMultiIBTestSet()
-	labeler := &testLabeler{data: make(map[string]map[string]string)}
+	labeler := &testLabeler{}
 	data := map[string]map[string]string{
 		"Node104": {
 			"network.topology.nvidia.com/accelerator": "B1",
@@ -135,13 +151,96 @@ func TestApplyNodeLabelsWithBlock(t *testing.T) {
 		},
 	}
 
-	err := NewTopologyLabeler().ApplyNodeLabels(context.TODO(), root, labeler)
+	err := NewTopologyLabeler(&Params{Labels: DefaultLabelKeys()}).ApplyNodeLabels(context.TODO(), root, labeler)
 	require.NoError(t, err)
 	require.Equal(t, data, labeler.data)
 }
 
-func TestInitLabels(t *testing.T) {
-	InitLabels("a", "b", "c", "d")
-	require.Equal(t, []string{"b", "c", "d"}, switchNetworkHierarchy)
-	require.Equal(t, "a", labelAccelerator)
+func TestTopologyLevels(t *testing.T) {
+
+	tree, _ := translate.GetTreeTestSet(false)
+	require.Equal(t, []string{DefaultLabelSpine, DefaultLabelLeaf}, TopologyLevels(tree, DefaultLabelKeys()))
+
+	block, _ := translate.GetBlockWithMultiIBTestSet()
+	require.Equal(t, []string{DefaultLabelCore, DefaultLabelSpine, DefaultLabelLeaf, DefaultLabelAccelerator}, TopologyLevels(block, DefaultLabelKeys()))
+
+	require.Empty(t, TopologyLevels(nil, DefaultLabelKeys()))
+}
+
+func TestApplyNodeLabelsWithExtraLabels(t *testing.T) {
+	root, _ := translate.GetBlockWithMultiIBTestSet()
+	labeler := &testLabeler{}
+	keys := LabelKeys{
+		Accelerator:  "example.com/accelerator",
+		Leaf:         "example.com/leaf",
+		Spine:        "example.com/spine",
+		Core:         "example.com/core",
+		BlockIndex:   "example.com/block",
+		LeafPosition: "example.com/position",
+	}
+
+	err := NewTopologyLabeler(&Params{Labels: keys}).ApplyNodeLabels(context.TODO(), root, labeler)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"example.com/accelerator": "B2",
+		"example.com/leaf":        "S3",
+		"example.com/spine":       "S1",
+		"example.com/core":        "IB2",
+		"example.com/block":       "2",
+		"example.com/position":    "2",
+	}, labeler.data["Node202"])
+	require.Equal(t, "1", labeler.data["Node401"]["example.com/position"])
+	require.Equal(t, "4", labeler.data["Node401"]["example.com/block"])
+}
+
+func TestApplyNodeLabelsWithAnnotations(t *testing.T) {
+	root, _ := translate.GetTreeTestSet(true)
+	labeler := &testLabeler{}
+
+	err := NewTopologyLabeler(&Params{Labels: DefaultLabelKeys(), Mode: ModeAnnotations}).ApplyNodeLabels(context.TODO(), root, labeler)
+	require.NoError(t, err)
+	// annotation values are not hashed
+	require.Len(t, labeler.data["Node304"][DefaultLabelLeaf], 64)
+}
+
+func TestLabelKeys(t *testing.T) {
+	keys := LabelKeys{Leaf: "example.com/leaf"}
+	keys.setDefaults(DefaultLabelKeys())
+	require.Equal(t, LabelKeys{
+		Accelerator: DefaultLabelAccelerator,
+		Leaf:        "example.com/leaf",
+		Spine:       DefaultLabelSpine,
+		Core:        DefaultLabelCore,
+	}, keys)
+	require.NoError(t, keys.validate())
+	require.Equal(t, []string{DefaultLabelAccelerator, "example.com/leaf", DefaultLabelSpine, DefaultLabelCore}, keys.managed())
+
+	keys.BlockIndex = DefaultLabelSpine
+	require.ErrorContains(t, keys.validate(), `duplicate label key "network.topology.nvidia.com/spine"`)
+
+	keys.BlockIndex = "bad key"
+	require.ErrorContains(t, keys.validate(), `invalid label key "bad key"`)
+}
+
+func TestNewLabelKeys(t *testing.T) {
+	_, err := NewLabelKeys("", DefaultLabelSpine, "", "")
+	require.ErrorContains(t, err, `duplicate label key "network.topology.nvidia.com/spine"`)
+
+	defaults, err := NewLabelKeys("", "example.com/leaf", "", "example.com/core")
+	require.NoError(t, err)
+
+	// the request keys override the defaults per key
+	p, err := getParameters(map[string]any{"labels": map[string]any{"core": "example.org/core"}}, defaults)
+	require.NoError(t, err)
+	require.Equal(t, LabelKeys{
+		Accelerator: DefaultLabelAccelerator,
+		Leaf:        "example.com/leaf",
+		Spine:       DefaultLabelSpine,
+		Core:        "example.org/core",
+	}, p.Labels)
+
+	// the defaults are not shared between the loaders
+	p, err = getParameters(nil, DefaultLabelKeys())
+	require.NoError(t, err)
+	require.Equal(t, DefaultLabelKeys(), p.Labels)
 }
@@ -0,0 +1,336 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	// ReasonTopologyMissing is the reason of the events and conditions of the nodes without network topology
+	ReasonTopologyMissing = "TopologyMissing"
+	// ReasonTopologyFound is the reason of the events and conditions of the nodes whose network topology appeared
+	ReasonTopologyFound = "TopologyFound"
+
+	// eventComponent is the source component of the node events
+	eventComponent = "topograph"
+)
+
+// MissingTopology specifies how the nodes without network topology, i.e., under the NoTopology switch,
+// are marked. The marks are removed once the nodes have topology.
+type MissingTopology struct {
+	// Taint (optional) is the node taint in the "key[=value]:effect" form
+	Taint string `mapstructure:"taint"`
+	// Label (optional) is the node label in the "key[=value]" form; the default value is "true"
+	Label string `mapstructure:"label"`
+	// Condition (optional) is the type of the Node condition set to True,
+	// and to False once the node has topology
+	Condition string `mapstructure:"condition"`
+
+	// derived fields
+	taint      *corev1.Taint
+	labelKey   string
+	labelValue string
+}
+
+func (m *MissingTopology) enabled() bool {
+	return len(m.Taint) != 0 || len(m.Label) != 0 || len(m.Condition) != 0
+}
+
+// parse validates the marks and sets the derived fields
+func (m *MissingTopology) parse() error {
+	if len(m.Taint) != 0 {
+		taint, err := parseTaint(m.Taint)
+		if err != nil {
+			return err
+		}
+		m.taint = taint
+	}
+
+	if len(m.Label) != 0 {
+		key, val, ok := strings.Cut(m.Label, "=")
+		if !ok {
+			val = "true"
+		}
+		if err := validateKeyValue(key, val); err != nil {
+			return fmt.Errorf("invalid label %q: %v", m.Label, err)
+		}
+		m.labelKey, m.labelValue = key, val
+	}
+
+	if len(m.Condition) != 0 {
+		if errs := validation.IsQualifiedName(m.Condition); len(errs) != 0 {
+			return fmt.Errorf("invalid condition %q: %s", m.Condition, strings.Join(errs, "; "))
+		}
+	}
+
+	return nil
+}
+
+// parseTaint parses the taint in the "key[=value]:effect" form
+func parseTaint(spec string) (*corev1.Taint, error) {
+	keyValue, effect, ok := strings.Cut(spec, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid taint %q: must be in the key[=value]:effect form", spec)
+	}
+
+	switch corev1.TaintEffect(effect) {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		return nil, fmt.Errorf("invalid taint %q: unsupported effect %q", spec, effect)
+	}
+
+	key, val, _ := strings.Cut(keyValue, "=")
+	if err := validateKeyValue(key, val); err != nil {
+		return nil, fmt.Errorf("invalid taint %q: %v", spec, err)
+	}
+
+	return &corev1.Taint{Key: key, Value: val, Effect: corev1.TaintEffect(effect)}, nil
+}
+
+func validateKeyValue(key, val string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+		return fmt.Errorf("invalid key %q: %s", key, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsValidLabelValue(val); len(errs) != 0 {
+		return fmt.Errorf("invalid value %q: %s", val, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// missingTopologyNodes returns the names of the nodes under the NoTopology switch,
+// and of the nodes with topology, either under a switch or in an accelerator domain
+func missingTopologyNodes(graph *topology.Graph) (map[string]bool, map[string]bool) {
+	missing := make(map[string]bool)
+	found := make(map[string]bool)
+
+	var visit func(v *topology.Vertex, noTopology bool)
+	visit = func(v *topology.Vertex, noTopology bool) {
+		if len(v.Vertices) == 0 {
+			if noTopology {
+				missing[v.Name] = true
+			} else {
+				found[v.Name] = true
+			}
+			return
+		}
+		for _, w := range v.Vertices {
+			visit(w, noTopology || w.ID == topology.NoTopology)
+		}
+	}
+	if treeRoot := graph.ToTree().Tiers; treeRoot != nil {
+		visit(treeRoot, false)
+	}
+
+	for _, domain := range graph.Domains {
+		for nodeName := range domain {
+			found[nodeName] = true
+		}
+	}
+	for nodeName := range missing {
+		delete(found, nodeName)
+	}
+
+	return missing, found
+}
+
+// markMissingTopology marks the nodes without network topology and unmarks the nodes with topology,
+// and emits an event on each change. The nodes absent from the topology are left as they are.
+func (eng *K8sEngine) markMissingTopology(ctx context.Context, graph *topology.Graph) error {
+	missing, found := missingTopologyNodes(graph)
+
+	nodes, err := eng.getNodes(ctx, maps.Keys(missing))
+	if err != nil {
+		return err
+	}
+	// the nodes with topology not matched by the node selector may have been marked by a previous request
+	for nodeName := range found {
+		if _, ok := nodes[nodeName]; ok {
+			continue
+		}
+		node, err := eng.client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			return err
+		}
+		nodes[nodeName] = node
+	}
+
+	g, ctx := eng.newNodeGroup(ctx)
+	for _, node := range nodes {
+		if !missing[node.Name] && !found[node.Name] {
+			continue
+		}
+		g.Go(func() error {
+			return eng.setMissingTopology(ctx, node, missing[node.Name])
+		})
+	}
+
+	return g.Wait()
+}
+
+// setMissingTopology sets or removes the marks of the node without network topology
+func (eng *K8sEngine) setMissingTopology(ctx context.Context, node *corev1.Node, missing bool) error {
+	m := &eng.params.MissingTopology
+	changed := false
+
+	if m.taint != nil || len(m.labelKey) != 0 {
+		if updateMissingTopologyMarks(node.DeepCopy(), m, missing) {
+			err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				current, err := eng.client.CoreV1().Nodes().Get(ctx, node.Name, metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				if !updateMissingTopologyMarks(current, m, missing) {
+					return nil
+				}
+				_, err = eng.client.CoreV1().Nodes().Update(ctx, current, metav1.UpdateOptions{FieldManager: FieldManager})
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update missing topology marks on node %s: %v", node.Name, err)
+			}
+			changed = true
+		}
+	}
+
+	if len(m.Condition) != 0 {
+		status := corev1.ConditionFalse
+		reason, message := ReasonTopologyFound, "network topology found for the node"
+		if missing {
+			status = corev1.ConditionTrue
+			reason, message = ReasonTopologyMissing, "provider reported no network topology for the node"
+		}
+
+		var current *corev1.NodeCondition
+		for i := range node.Status.Conditions {
+			if string(node.Status.Conditions[i].Type) == m.Condition {
+				current = &node.Status.Conditions[i]
+			}
+		}
+		// a node that never missed topology gets no condition
+		if (current == nil && missing) || (current != nil && current.Status != status) {
+			now := metav1.Now()
+			data, err := json.Marshal(map[string]any{"status": map[string]any{"conditions": []corev1.NodeCondition{{
+				Type:               corev1.NodeConditionType(m.Condition),
+				Status:             status,
+				LastHeartbeatTime:  now,
+				LastTransitionTime: now,
+				Reason:             reason,
+				Message:            message,
+			}}}})
+			if err != nil {
+				return err
+			}
+			_, err = eng.client.CoreV1().Nodes().PatchStatus(ctx, node.Name, data)
+			if err != nil {
+				return fmt.Errorf("failed to set condition %s on node %s: %v", m.Condition, node.Name, err)
+			}
+			changed = true
+		}
+	}
+
+	if changed {
+		eng.emitMissingTopologyEvent(ctx, node, missing)
+	}
+	return nil
+}
+
+// updateMissingTopologyMarks sets or removes the taint and label of the node without network topology,
+// and returns true if the node changed
+func updateMissingTopologyMarks(node *corev1.Node, m *MissingTopology, missing bool) bool {
+	changed := false
+
+	if m.taint != nil {
+		idx := -1
+		for i, taint := range node.Spec.Taints {
+			if taint.MatchTaint(m.taint) {
+				idx = i
+			}
+		}
+		switch {
+		case missing && idx < 0:
+			node.Spec.Taints = append(node.Spec.Taints, *m.taint)
+			changed = true
+		case missing && node.Spec.Taints[idx].Value != m.taint.Value:
+			node.Spec.Taints[idx].Value = m.taint.Value
+			changed = true
+		case !missing && idx >= 0:
+			node.Spec.Taints = append(node.Spec.Taints[:idx], node.Spec.Taints[idx+1:]...)
+			changed = true
+		}
+	}
+
+	if len(m.labelKey) != 0 {
+		val, ok := node.Labels[m.labelKey]
+		switch {
+		case missing && (!ok || val != m.labelValue):
+			if node.Labels == nil {
+				node.Labels = make(map[string]string)
+			}
+			node.Labels[m.labelKey] = m.labelValue
+			changed = true
+		case !missing && ok:
+			delete(node.Labels, m.labelKey)
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// emitMissingTopologyEvent emits the event of the node without network topology,
+// or of the node whose topology appeared. Failures are logged, as events are best effort.
+func (eng *K8sEngine) emitMissingTopologyEvent(ctx context.Context, node *corev1.Node, missing bool) {
+	eventType, reason, message := corev1.EventTypeNormal, ReasonTopologyFound, "Network topology found for the node"
+	if missing {
+		eventType, reason, message = corev1.EventTypeWarning, ReasonTopologyMissing, "Provider reported no network topology for the node"
+	}
+
+	now := metav1.NewTime(time.Now())
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			// the events of cluster-scoped objects are stored in the default namespace
+			Name:      fmt.Sprintf("%s.%x", node.Name, now.UnixNano()),
+			Namespace: metav1.NamespaceDefault,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Node",
+			Name:       node.Name,
+			UID:        node.UID,
+		},
+		Reason:              reason,
+		Message:             message,
+		Type:                eventType,
+		Source:              corev1.EventSource{Component: eventComponent},
+		FirstTimestamp:      now,
+		LastTimestamp:       now,
+		Count:               1,
+		ReportingController: eventComponent,
+	}
+
+	klog.Infof("Node %s: %s", node.Name, message)
+	if _, err := eng.client.CoreV1().Events(metav1.NamespaceDefault).Create(ctx, event, metav1.CreateOptions{}); err != nil {
+		klog.Warningf("failed to emit event %s for node %s: %v", reason, node.Name, err)
+	}
+}
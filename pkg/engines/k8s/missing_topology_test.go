@@ -0,0 +1,231 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestMissingTopologyParse(t *testing.T) {
+	testCases := []struct {
+		name  string
+		in    MissingTopology
+		taint *corev1.Taint
+		label [2]string
+		err   string
+	}{
+		{
+			name: "Case 1: empty",
+		},
+		{
+			name: "Case 2: all marks",
+			in: MissingTopology{
+				Taint:     "topograph.nvidia.com/no-topology=true:NoSchedule",
+				Label:     "topograph.nvidia.com/no-topology",
+				Condition: "TopologyMissing",
+			},
+			taint: &corev1.Taint{Key: "topograph.nvidia.com/no-topology", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+			label: [2]string{"topograph.nvidia.com/no-topology", "true"},
+		},
+		{
+			name:  "Case 3: taint and label without value",
+			in:    MissingTopology{Taint: "no-topology:PreferNoSchedule", Label: "no-topology=yes"},
+			taint: &corev1.Taint{Key: "no-topology", Effect: corev1.TaintEffectPreferNoSchedule},
+			label: [2]string{"no-topology", "yes"},
+		},
+		{
+			name: "Case 4: taint without effect",
+			in:   MissingTopology{Taint: "no-topology"},
+			err:  `invalid taint "no-topology": must be in the key[=value]:effect form`,
+		},
+		{
+			name: "Case 5: unsupported taint effect",
+			in:   MissingTopology{Taint: "no-topology:NoRun"},
+			err:  `invalid taint "no-topology:NoRun": unsupported effect "NoRun"`,
+		},
+		{
+			name: "Case 6: invalid label",
+			in:   MissingTopology{Label: "no topology"},
+			err:  `invalid label "no topology": invalid key "no topology"`,
+		},
+		{
+			name: "Case 7: invalid condition",
+			in:   MissingTopology{Condition: "Topology Missing"},
+			err:  `invalid condition "Topology Missing"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.in.parse()
+			if len(tc.err) != 0 {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.taint, tc.in.taint)
+			require.Equal(t, tc.label, [2]string{tc.in.labelKey, tc.in.labelValue})
+		})
+	}
+}
+
+func getMissingTopologyTestGraph() *topology.Graph {
+	//
+	//      S1          no-topology
+	//    /    \             |
+	//   n2    n3           n1
+	//
+	return &topology.Graph{
+		Tiers: &topology.Vertex{
+			Vertices: map[string]*topology.Vertex{
+				"S1": {
+					ID: "S1",
+					Vertices: map[string]*topology.Vertex{
+						"i2": {ID: "i2", Name: "n2"},
+						"i3": {ID: "i3", Name: "n3"},
+					},
+				},
+				topology.NoTopology: {
+					ID:       topology.NoTopology,
+					Vertices: map[string]*topology.Vertex{"i1": {ID: "i1", Name: "n1"}},
+				},
+			},
+		},
+		Domains: topology.DomainMap{"nvl1": {"n1": nil, "n5": nil}},
+	}
+}
+
+func TestMissingTopologyNodes(t *testing.T) {
+	missing, found := missingTopologyNodes(getMissingTopologyTestGraph())
+	require.Equal(t, map[string]bool{"n1": true}, missing)
+	require.Equal(t, map[string]bool{"n2": true, "n3": true, "n5": true}, found)
+}
+
+func TestMarkMissingTopology(t *testing.T) {
+	ctx := context.TODO()
+	taint := corev1.Taint{Key: "no-topology", Effect: corev1.TaintEffectNoSchedule}
+	otherTaint := corev1.Taint{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule}
+	marked := func(name string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"no-topology": "true", "pool": "gpu"}},
+			Spec:       corev1.NodeSpec{Taints: []corev1.Taint{otherTaint, taint}},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: "TopologyMissing", Status: corev1.ConditionTrue, Reason: ReasonTopologyMissing},
+			}},
+		}
+	}
+
+	client := fake.NewSimpleClientset(
+		// no topology
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1", Labels: map[string]string{"pool": "gpu"}}},
+		// topology appeared
+		marked("n2"),
+		// never missed topology
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n3", Labels: map[string]string{"pool": "gpu"}}},
+		// absent from the topology
+		marked("n4"),
+	)
+
+	p, err := getParameters(map[string]any{
+		"missingTopology": map[string]any{
+			"taint":     "no-topology:NoSchedule",
+			"label":     "no-topology",
+			"condition": "TopologyMissing",
+		},
+	}, DefaultLabelKeys())
+	require.NoError(t, err)
+	eng := &K8sEngine{client: client, params: p}
+
+	require.NoError(t, eng.markMissingTopology(ctx, getMissingTopologyTestGraph()))
+
+	getNode := func(name string) *corev1.Node {
+		node, err := client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return node
+	}
+	condition := func(node *corev1.Node) *corev1.NodeCondition {
+		for i := range node.Status.Conditions {
+			if node.Status.Conditions[i].Type == "TopologyMissing" {
+				return &node.Status.Conditions[i]
+			}
+		}
+		return nil
+	}
+
+	n1 := getNode("n1")
+	require.Equal(t, []corev1.Taint{taint}, n1.Spec.Taints)
+	require.Equal(t, map[string]string{"no-topology": "true", "pool": "gpu"}, n1.Labels)
+	require.Equal(t, corev1.ConditionTrue, condition(n1).Status)
+	require.Equal(t, ReasonTopologyMissing, condition(n1).Reason)
+
+	n2 := getNode("n2")
+	require.Equal(t, []corev1.Taint{otherTaint}, n2.Spec.Taints)
+	require.Equal(t, map[string]string{"pool": "gpu"}, n2.Labels)
+	require.Len(t, n2.Status.Conditions, 2)
+	require.Equal(t, corev1.ConditionFalse, condition(n2).Status)
+	require.Equal(t, ReasonTopologyFound, condition(n2).Reason)
+
+	n3 := getNode("n3")
+	require.Empty(t, n3.Spec.Taints)
+	require.Nil(t, condition(n3))
+
+	require.Equal(t, marked("n4"), getNode("n4"))
+
+	events, err := client.CoreV1().Events(metav1.NamespaceDefault).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	reasons := make(map[string]string)
+	for _, event := range events.Items {
+		require.Equal(t, "Node", event.InvolvedObject.Kind)
+		reasons[event.InvolvedObject.Name] = event.Reason
+	}
+	require.Equal(t, map[string]string{"n1": ReasonTopologyMissing, "n2": ReasonTopologyFound}, reasons)
+
+	// no changes, no events
+	require.NoError(t, eng.markMissingTopology(ctx, getMissingTopologyTestGraph()))
+	events, err = client.CoreV1().Events(metav1.NamespaceDefault).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 2)
+}
+
+func TestMarkMissingTopologyWithNodeSelector(t *testing.T) {
+	ctx := context.TODO()
+	taint := corev1.Taint{Key: "no-topology", Effect: corev1.TaintEffectNoSchedule}
+	client := fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1", Labels: map[string]string{"pool": "gpu"}}},
+		// marked by a previous request, and no longer matched by the node selector
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "n2", Labels: map[string]string{"pool": "cpu"}},
+			Spec:       corev1.NodeSpec{Taints: []corev1.Taint{taint}},
+		},
+	)
+
+	p, err := getParameters(map[string]any{
+		"nodeSelector":    map[string]any{"pool": "gpu"},
+		"missingTopology": map[string]any{"taint": "no-topology:NoSchedule"},
+	}, DefaultLabelKeys())
+	require.NoError(t, err)
+	eng := &K8sEngine{client: client, params: p}
+
+	// n3 and n5 are not in the cluster
+	require.NoError(t, eng.markMissingTopology(ctx, getMissingTopologyTestGraph()))
+
+	n1, err := client.CoreV1().Nodes().Get(ctx, "n1", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []corev1.Taint{taint}, n1.Spec.Taints)
+
+	n2, err := client.CoreV1().Nodes().Get(ctx, "n2", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, n2.Spec.Taints)
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package kueue
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/engines/k8s"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	NAME = "kueue"
+
+	DefaultTopologyName = "topograph"
+)
+
+// KueueEngine applies the topology node labels like the k8s engine,
+// and publishes the label hierarchy as a Kueue Topology object
+type KueueEngine struct {
+	*k8s.K8sEngine
+	client dynamic.Interface
+	params *Params
+}
+
+type Params struct {
+	// NodeSelector (optional) specifies nodes participating in the topology.
+	// It is also used as the node labels of the ResourceFlavor.
+	NodeSelector map[string]string `mapstructure:"nodeSelector"`
+	// TopologyName (optional) is the name of the Kueue Topology object
+	TopologyName string `mapstructure:"topologyName"`
+	// ResourceFlavor (optional) is the name of the Kueue ResourceFlavor referencing the Topology
+	ResourceFlavor string `mapstructure:"resourceFlavor"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(ctx context.Context, params engines.Config) (engines.Engine, *httperr.Error) {
+	return NewLoader(k8s.DefaultLabelKeys())(ctx, params)
+}
+
+// NewLoader returns the loader of the engine with the default keys of the "labels" parameter,
+// e.g., from the command line
+func NewLoader(labelKeys k8s.LabelKeys) engines.Loader {
+	return func(ctx context.Context, params engines.Config) (engines.Engine, *httperr.Error) {
+		return load(ctx, params, labelKeys)
+	}
+}
+
+func load(ctx context.Context, params engines.Config, labelKeys k8s.LabelKeys) (engines.Engine, *httperr.Error) {
+	p, err := getParameters(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	eng, httpErr := k8s.NewLoader(labelKeys)(ctx, params)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	// Kueue Topology levels refer to node labels
+	if mode := eng.(*k8s.K8sEngine).Mode(); mode != k8s.ModeLabels {
+		return nil, httperr.NewError(http.StatusBadRequest, fmt.Sprintf("unsupported mode %q", mode))
+	}
+
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+	}
+
+	return &KueueEngine{
+		K8sEngine: eng.(*k8s.K8sEngine),
+		client:    client,
+		params:    p,
+	}, nil
+}
+
+func getParameters(params engines.Config) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	if len(p.TopologyName) == 0 {
+		p.TopologyName = DefaultTopologyName
+	}
+
+	// Kueue requires node labels in a ResourceFlavor with a topology
+	if len(p.ResourceFlavor) != 0 && len(p.NodeSelector) == 0 {
+		return nil, fmt.Errorf("must specify engine parameter %q with %q", "nodeSelector", "resourceFlavor")
+	}
+
+	return p, nil
+}
+
+func (eng *KueueEngine) GenerateOutput(ctx context.Context, graph *topology.Graph, params map[string]any) ([]byte, *httperr.Error) {
+	if _, err := eng.K8sEngine.GenerateOutput(ctx, graph, params); err != nil {
+		return nil, err
+	}
+
+	levels := k8s.TopologyLevels(graph, eng.LabelKeys())
+	if len(levels) == 0 {
+		return nil, httperr.NewError(http.StatusBadRequest, "no topology levels to publish")
+	}
+
+	if err := applyTopology(ctx, eng.client, eng.params.TopologyName, levels); err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+	}
+
+	if len(eng.params.ResourceFlavor) != 0 {
+		err := applyResourceFlavor(ctx, eng.client, eng.params.ResourceFlavor, eng.params.TopologyName, eng.params.NodeSelector)
+		if err != nil {
+			return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+		}
+	}
+
+	return []byte("OK\n"), nil
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package kueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamedLoader(t *testing.T) {
+	name, _ := NamedLoader()
+	require.Equal(t, NAME, name)
+}
+
+func TestGetParameters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]any
+		want   *Params
+		err    string
+	}{
+		{
+			name:   "Case 1: default topology name",
+			params: nil,
+			want:   &Params{TopologyName: DefaultTopologyName},
+		},
+		{
+			name: "Case 2: valid params",
+			params: map[string]any{
+				"nodeSelector":   map[string]string{"pool": "gpu"},
+				"topologyName":   "fabric",
+				"resourceFlavor": "gpu",
+			},
+			want: &Params{
+				NodeSelector:   map[string]string{"pool": "gpu"},
+				TopologyName:   "fabric",
+				ResourceFlavor: "gpu",
+			},
+		},
+		{
+			name:   "Case 3: resource flavor without node selector",
+			params: map[string]any{"resourceFlavor": "gpu"},
+			err:    `must specify engine parameter "nodeSelector" with "resourceFlavor"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := getParameters(tc.params)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
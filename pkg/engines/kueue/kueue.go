@@ -0,0 +1,100 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package kueue
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+)
+
+const kueueGroup = "kueue.x-k8s.io"
+
+var (
+	topologyGVR       = schema.GroupVersionResource{Group: kueueGroup, Version: "v1alpha1", Resource: "topologies"}
+	resourceFlavorGVR = schema.GroupVersionResource{Group: kueueGroup, Version: "v1beta1", Resource: "resourceflavors"}
+)
+
+// applyTopology creates or updates the Kueue Topology with the given node label keys,
+// ordered from the broadest to the narrowest level
+func applyTopology(ctx context.Context, client dynamic.Interface, name string, levels []string) error {
+	specLevels := make([]any, 0, len(levels))
+	for _, level := range levels {
+		specLevels = append(specLevels, map[string]any{"nodeLabel": level})
+	}
+
+	return applyObject(ctx, client, topologyGVR, "Topology", name, map[string]any{"levels": specLevels})
+}
+
+// applyResourceFlavor creates or updates the Kueue ResourceFlavor referencing the Topology.
+// Other fields of an existing ResourceFlavor, such as node taints and tolerations, are preserved.
+func applyResourceFlavor(ctx context.Context, client dynamic.Interface, name, topologyName string, nodeLabels map[string]string) error {
+	labels := make(map[string]any, len(nodeLabels))
+	for key, val := range nodeLabels {
+		labels[key] = val
+	}
+
+	return applyObject(ctx, client, resourceFlavorGVR, "ResourceFlavor", name, map[string]any{
+		"nodeLabels":   labels,
+		"topologyName": topologyName,
+	})
+}
+
+// applyObject creates the cluster-scoped object with the given spec fields,
+// or sets these fields in the existing object
+func applyObject(ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, kind, name string, spec map[string]any) error {
+	res := client.Resource(gvr)
+
+	obj, err := res.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get %s %q: %v", kind, name, err)
+		}
+
+		obj = &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": gvr.GroupVersion().String(),
+			"kind":       kind,
+			"metadata":   map[string]any{"name": name},
+			"spec":       spec,
+		}}
+		klog.Infof("Creating %s %q", kind, name)
+		if _, err = res.Create(ctx, obj, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create %s %q: %v", kind, name, err)
+		}
+		return nil
+	}
+
+	current, _, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return fmt.Errorf("invalid spec in %s %q: %v", kind, name, err)
+	}
+	if current == nil {
+		current = make(map[string]any)
+	}
+	updated := maps.Clone(current)
+	maps.Copy(updated, spec)
+	if reflect.DeepEqual(current, updated) {
+		klog.Infof("%s %q is up to date", kind, name)
+		return nil
+	}
+
+	if err = unstructured.SetNestedMap(obj.Object, updated, "spec"); err != nil {
+		return fmt.Errorf("failed to set spec in %s %q: %v", kind, name, err)
+	}
+	klog.Infof("Updating %s %q", kind, name)
+	if _, err = res.Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update %s %q: %v", kind, name, err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package kueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/NVIDIA/topograph/pkg/engines/k8s"
+)
+
+func newFakeClient(objects ...runtime.Object) *fake.FakeDynamicClient {
+	return fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		topologyGVR:       "TopologyList",
+		resourceFlavorGVR: "ResourceFlavorList",
+	}, objects...)
+}
+
+func getSpec(t *testing.T, client *fake.FakeDynamicClient, gvr schema.GroupVersionResource, name string) map[string]any {
+	obj, err := client.Resource(gvr).Get(context.TODO(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	spec, _, err := unstructured.NestedMap(obj.Object, "spec")
+	require.NoError(t, err)
+	return spec
+}
+
+func TestApplyTopology(t *testing.T) {
+	ctx := context.TODO()
+	client := newFakeClient()
+
+	err := applyTopology(ctx, client, "topograph", []string{k8s.DefaultLabelSpine, k8s.DefaultLabelLeaf})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"levels": []any{
+		map[string]any{"nodeLabel": k8s.DefaultLabelSpine},
+		map[string]any{"nodeLabel": k8s.DefaultLabelLeaf},
+	}}, getSpec(t, client, topologyGVR, "topograph"))
+
+	// unchanged levels do not update the object
+	client.ClearActions()
+	err = applyTopology(ctx, client, "topograph", []string{k8s.DefaultLabelSpine, k8s.DefaultLabelLeaf})
+	require.NoError(t, err)
+	require.Len(t, client.Actions(), 1)
+	require.Equal(t, "get", client.Actions()[0].GetVerb())
+
+	err = applyTopology(ctx, client, "topograph", []string{k8s.DefaultLabelLeaf, k8s.DefaultLabelAccelerator})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"levels": []any{
+		map[string]any{"nodeLabel": k8s.DefaultLabelLeaf},
+		map[string]any{"nodeLabel": k8s.DefaultLabelAccelerator},
+	}}, getSpec(t, client, topologyGVR, "topograph"))
+}
+
+func TestApplyResourceFlavor(t *testing.T) {
+	existing := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": resourceFlavorGVR.GroupVersion().String(),
+		"kind":       "ResourceFlavor",
+		"metadata":   map[string]any{"name": "gpu"},
+		"spec": map[string]any{
+			"nodeLabels": map[string]any{"old": "label"},
+			"tolerations": []any{
+				map[string]any{"key": "nvidia.com/gpu", "operator": "Exists", "effect": "NoSchedule"},
+			},
+		},
+	}}
+	client := newFakeClient(existing)
+
+	err := applyResourceFlavor(context.TODO(), client, "gpu", "topograph", map[string]string{"pool": "gpu"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"nodeLabels":   map[string]any{"pool": "gpu"},
+		"topologyName": "topograph",
+		"tolerations": []any{
+			map[string]any{"key": "nvidia.com/gpu", "operator": "Exists", "effect": "NoSchedule"},
+		},
+	}, getSpec(t, client, resourceFlavorGVR, "gpu"))
+
+	err = applyResourceFlavor(context.TODO(), client, "cpu", "topograph", map[string]string{"pool": "cpu"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"nodeLabels":   map[string]any{"pool": "cpu"},
+		"topologyName": "topograph",
+	}, getSpec(t, client, resourceFlavorGVR, "cpu"))
+}
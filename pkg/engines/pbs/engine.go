@@ -0,0 +1,214 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pbs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/exec"
+	"github.com/NVIDIA/topograph/internal/files"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+const (
+	NAME = "pbs"
+
+	DefaultSwitchResource      = "switch"
+	DefaultAcceleratorResource = "nvl"
+
+	TopologyHeader = `#
+# PBS placement sets generated by Topograph.
+# Apply with: qmgr < %s
+#
+`
+)
+
+var reResourceName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// runQmgr indirects the qmgr execution for tests
+var runQmgr = func(ctx context.Context, cmd string) error {
+	stdout, err := exec.Exec(ctx, "qmgr", []string{"-c", cmd}, nil)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("stdout: %s", stdout.String())
+	return nil
+}
+
+type PbsEngine struct {
+	params *Params
+}
+
+type Params struct {
+	// SwitchResource is the string_array vnode resource listing the switches above the node
+	SwitchResource string `mapstructure:"switchResource"`
+	// AcceleratorResource is the string_array vnode resource holding the accelerator domain of the node
+	AcceleratorResource string `mapstructure:"acceleratorResource"`
+	TopologyConfigPath  string `mapstructure:"topologyConfigPath"`
+	// Execute applies the qmgr commands
+	Execute bool `mapstructure:"execute"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(_ context.Context, params engines.Config) (engines.Engine, *httperr.Error) {
+	p, err := getParameters(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return &PbsEngine{
+		params: p,
+	}, nil
+}
+
+func getParameters(params engines.Config) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	if len(p.SwitchResource) == 0 {
+		p.SwitchResource = DefaultSwitchResource
+	}
+	if len(p.AcceleratorResource) == 0 {
+		p.AcceleratorResource = DefaultAcceleratorResource
+	}
+
+	for _, name := range []string{p.SwitchResource, p.AcceleratorResource} {
+		if !reResourceName.MatchString(name) {
+			return nil, fmt.Errorf("invalid resource name %q", name)
+		}
+	}
+	if p.SwitchResource == p.AcceleratorResource {
+		return nil, fmt.Errorf("switchResource and acceleratorResource must differ")
+	}
+
+	return p, nil
+}
+
+func (eng *PbsEngine) GenerateOutput(ctx context.Context, graph *topology.Graph, _ map[string]any) ([]byte, *httperr.Error) {
+	resources, cmds := getCommands(translate.GetNodePlacements(graph), eng.params)
+	if len(resources) == 0 {
+		return nil, httperr.NewError(http.StatusBadRequest, "no topology data for placement sets")
+	}
+
+	var sb strings.Builder
+	if len(eng.params.TopologyConfigPath) != 0 {
+		fmt.Fprintf(&sb, TopologyHeader, eng.params.TopologyConfigPath)
+	}
+	for _, name := range resources {
+		fmt.Fprintln(&sb, createResourceCommand(name))
+	}
+	for _, cmd := range cmds {
+		fmt.Fprintln(&sb, cmd)
+	}
+	data := []byte(sb.String())
+
+	if len(eng.params.TopologyConfigPath) != 0 {
+		klog.Infof("Writing PBS commands in %q", eng.params.TopologyConfigPath)
+		if err := files.Create(eng.params.TopologyConfigPath, data); err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	if eng.params.Execute {
+		if err := execute(ctx, resources, cmds); err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	if len(eng.params.TopologyConfigPath) == 0 && !eng.params.Execute {
+		return data, nil
+	}
+
+	return []byte("OK\n"), nil
+}
+
+func (eng *PbsEngine) GetComputeInstances(_ context.Context, _ any) ([]topology.ComputeInstances, *httperr.Error) {
+	return nil, httperr.NewError(http.StatusBadRequest,
+		"pbs engine requires nodes in the request or a provider that can supply compute instances")
+}
+
+// getCommands returns the vnode resources in use, and the qmgr commands
+// setting these resources on the vnodes and enabling the placement sets
+func getCommands(placements map[string]*translate.NodePlacement, p *Params) ([]string, []string) {
+	nodeNames := make([]string, 0, len(placements))
+	for nodeName := range placements {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+
+	var hasSwitches, hasDomains bool
+	cmds := []string{}
+	for _, nodeName := range nodeNames {
+		placement := placements[nodeName]
+		if len(placement.Domain) != 0 {
+			hasDomains = true
+			cmds = append(cmds, fmt.Sprintf("set node %s resources_available.%s = %q", nodeName, p.AcceleratorResource, placement.Domain))
+		}
+		if len(placement.Switches) != 0 {
+			hasSwitches = true
+			cmds = append(cmds, fmt.Sprintf("set node %s resources_available.%s = %q", nodeName, p.SwitchResource, strings.Join(placement.Switches, ",")))
+		}
+	}
+
+	resources := []string{}
+	if hasDomains {
+		resources = append(resources, p.AcceleratorResource)
+	}
+	if hasSwitches {
+		resources = append(resources, p.SwitchResource)
+	}
+	if len(resources) == 0 {
+		return nil, nil
+	}
+
+	cmds = append(cmds,
+		fmt.Sprintf("set server node_group_key = %q", strings.Join(resources, ",")),
+		"set server node_group_enable = true",
+	)
+
+	return resources, cmds
+}
+
+func createResourceCommand(name string) string {
+	return fmt.Sprintf("create resource %s type=string_array, flag=h", name)
+}
+
+// execute runs the qmgr commands, creating the resources that do not exist yet
+func execute(ctx context.Context, resources, cmds []string) error {
+	for _, name := range resources {
+		if err := runQmgr(ctx, "list resource "+name); err == nil {
+			klog.V(4).Infof("PBS resource %q exists", name)
+			continue
+		}
+		if err := runQmgr(ctx, createResourceCommand(name)); err != nil {
+			return err
+		}
+	}
+
+	for _, cmd := range cmds {
+		if err := runQmgr(ctx, cmd); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package pbs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+const treeCommands = `create resource switch type=string_array, flag=h
+set node Node201 resources_available.switch = "S2,S1"
+set node Node202 resources_available.switch = "S2,S1"
+set node Node205 resources_available.switch = "S2,S1"
+set node Node304 resources_available.switch = "S3,S1"
+set node Node305 resources_available.switch = "S3,S1"
+set node Node306 resources_available.switch = "S3,S1"
+set server node_group_key = "switch"
+set server node_group_enable = true
+`
+
+func TestNamedLoader(t *testing.T) {
+	name, _ := NamedLoader()
+	require.Equal(t, NAME, name)
+}
+
+func TestGetParameters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]any
+		want   *Params
+		err    string
+	}{
+		{
+			name:   "Case 1: default resources",
+			params: nil,
+			want:   &Params{SwitchResource: DefaultSwitchResource, AcceleratorResource: DefaultAcceleratorResource},
+		},
+		{
+			name:   "Case 2: valid params",
+			params: map[string]any{"switchResource": "ib_switch", "acceleratorResource": "nvlink", "topologyConfigPath": "/tmp/x", "execute": true},
+			want:   &Params{SwitchResource: "ib_switch", AcceleratorResource: "nvlink", TopologyConfigPath: "/tmp/x", Execute: true},
+		},
+		{
+			name:   "Case 3: invalid resource name",
+			params: map[string]any{"switchResource": "ib switch"},
+			err:    `invalid resource name "ib switch"`,
+		},
+		{
+			name:   "Case 4: same resource names",
+			params: map[string]any{"switchResource": "nvl"},
+			err:    "switchResource and acceleratorResource must differ",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := getParameters(tc.params)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGetCommands(t *testing.T) {
+	placements := map[string]*translate.NodePlacement{
+		"n2": {Switches: []string{"leaf2", "spine1"}, Domain: "nvl2"},
+		"n1": {Switches: []string{"leaf1", "spine1"}, Domain: "nvl1"},
+		"n3": {},
+		"n4": {Domain: "nvl2"},
+	}
+	p := &Params{SwitchResource: DefaultSwitchResource, AcceleratorResource: DefaultAcceleratorResource}
+
+	resources, cmds := getCommands(placements, p)
+	require.Equal(t, []string{"nvl", "switch"}, resources)
+	require.Equal(t, []string{
+		`set node n1 resources_available.nvl = "nvl1"`,
+		`set node n1 resources_available.switch = "leaf1,spine1"`,
+		`set node n2 resources_available.nvl = "nvl2"`,
+		`set node n2 resources_available.switch = "leaf2,spine1"`,
+		`set node n4 resources_available.nvl = "nvl2"`,
+		`set server node_group_key = "nvl,switch"`,
+		`set server node_group_enable = true`,
+	}, cmds)
+
+	resources, cmds = getCommands(map[string]*translate.NodePlacement{"n3": {}}, p)
+	require.Empty(t, resources)
+	require.Empty(t, cmds)
+}
+
+func TestGenerateOutput(t *testing.T) {
+	ctx := context.Background()
+	graph, _ := translate.GetTreeTestSet(false)
+	params := &Params{SwitchResource: DefaultSwitchResource, AcceleratorResource: DefaultAcceleratorResource}
+
+	out, herr := (&PbsEngine{params: params}).GenerateOutput(ctx, graph, nil)
+	require.Nil(t, herr)
+	require.Equal(t, treeCommands, string(out))
+
+	params.TopologyConfigPath = filepath.Join(t.TempDir(), "topology.qmgr")
+	out, herr = (&PbsEngine{params: params}).GenerateOutput(ctx, graph, nil)
+	require.Nil(t, herr)
+	require.Equal(t, "OK\n", string(out))
+	data, err := os.ReadFile(params.TopologyConfigPath)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf(TopologyHeader, params.TopologyConfigPath)+treeCommands, string(data))
+
+	_, herr = (&PbsEngine{params: params}).GenerateOutput(ctx, &topology.Graph{}, nil)
+	require.NotNil(t, herr)
+	require.Equal(t, http.StatusBadRequest, herr.Code())
+}
+
+func TestExecute(t *testing.T) {
+	defer func(f func(context.Context, string) error) { runQmgr = f }(runQmgr)
+
+	var executed []string
+	runQmgr = func(_ context.Context, cmd string) error {
+		executed = append(executed, cmd)
+		if cmd == "list resource nvl" {
+			return fmt.Errorf("unknown resource")
+		}
+		return nil
+	}
+
+	graph, _ := translate.GetTreeTestSet(false)
+	params := &Params{SwitchResource: DefaultSwitchResource, AcceleratorResource: DefaultAcceleratorResource, Execute: true}
+	out, herr := (&PbsEngine{params: params}).GenerateOutput(context.Background(), graph, nil)
+	require.Nil(t, herr)
+	require.Equal(t, "OK\n", string(out))
+	// the switch resource exists
+	require.Equal(t, "list resource switch", executed[0])
+	require.Equal(t, `set node Node201 resources_available.switch = "S2,S1"`, executed[1])
+	require.Equal(t, "set server node_group_enable = true", executed[len(executed)-1])
+	require.Len(t, executed, 9)
+
+	executed = nil
+	_, err := getParameters(nil)
+	require.NoError(t, err)
+	require.NoError(t, execute(context.Background(), []string{"nvl"}, nil))
+	require.Equal(t, []string{"list resource nvl", "create resource nvl type=string_array, flag=h"}, executed)
+
+	runQmgr = func(_ context.Context, _ string) error { return fmt.Errorf("qmgr failed") }
+	_, herr = (&PbsEngine{params: params}).GenerateOutput(context.Background(), graph, nil)
+	require.NotNil(t, herr)
+	require.Equal(t, http.StatusInternalServerError, herr.Code())
+}
+
+func TestGetComputeInstances(t *testing.T) {
+	eng := &PbsEngine{params: &Params{}}
+	cis, herr := eng.GetComputeInstances(context.Background(), nil)
+	require.Nil(t, cis)
+	require.NotNil(t, herr)
+	require.Equal(t, http.StatusBadRequest, herr.Code())
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package prometheus
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/metrics"
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+const NAME = "prometheus"
+
+// PrometheusEngine publishes the topology of the nodes as the topograph_node_topology_info
+// gauge on the /metrics endpoint
+type PrometheusEngine struct {
+	params *Params
+}
+
+type Params struct {
+	// Naming is the naming strategies of the switches and blocks, as in the SLURM engine
+	Naming topology.Naming `mapstructure:"naming"`
+	// IDMapPath is the ID map state file of the SLURM engine; it is read, but not written
+	IDMapPath string `mapstructure:"idMapPath"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(_ context.Context, params engines.Config) (engines.Engine, *httperr.Error) {
+	p, err := getParameters(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return &PrometheusEngine{params: p}, nil
+}
+
+func getParameters(params engines.Config) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	if err := p.Naming.Validate(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (eng *PrometheusEngine) GenerateOutput(_ context.Context, graph *topology.Graph, _ map[string]any) ([]byte, *httperr.Error) {
+	cfg := &translate.Config{Naming: eng.params.Naming}
+	if len(eng.params.IDMapPath) != 0 {
+		idMap, err := translate.ReadIDMap(eng.params.IDMapPath)
+		if err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+		cfg.IDMap = idMap
+	}
+
+	placements, httpErr := translate.GetNamedNodePlacements(graph, cfg)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	if len(placements) == 0 {
+		return nil, httperr.NewError(http.StatusBadRequest, "no compute nodes in the topology")
+	}
+
+	metrics.SetNodeTopology(getNodeTopology(placements))
+
+	return []byte("OK\n"), nil
+}
+
+func getNodeTopology(placements map[string]*translate.NodePlacement) []*metrics.NodeTopology {
+	nodes := make([]*metrics.NodeTopology, 0, len(placements))
+	for nodeName, placement := range placements {
+		node := &metrics.NodeTopology{
+			Node:        nodeName,
+			Instance:    placement.InstanceID,
+			Accelerator: placement.Domain,
+			Block:       placement.Block,
+		}
+		for i, sw := range placement.Switches {
+			switch i {
+			case 0:
+				node.Leaf = sw
+			case 1:
+				node.Spine = sw
+			case 2:
+				node.Core = sw
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func (eng *PrometheusEngine) GetComputeInstances(_ context.Context, _ any) ([]topology.ComputeInstances, *httperr.Error) {
+	return nil, httperr.NewError(http.StatusBadRequest,
+		"prometheus engine requires nodes in the request or a provider that can supply compute instances")
+}
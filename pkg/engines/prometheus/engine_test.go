@@ -0,0 +1,117 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+const (
+	metricName = "topograph_node_topology_info"
+	metricHead = `# HELP topograph_node_topology_info Network topology of the node.
+# TYPE topograph_node_topology_info gauge
+`
+)
+
+func TestNamedLoader(t *testing.T) {
+	name, _ := NamedLoader()
+	require.Equal(t, NAME, name)
+}
+
+func TestGenerateOutput(t *testing.T) {
+	ctx := context.Background()
+	graph, _ := translate.GetBlockWithMultiIBTestSet()
+	eng := &PrometheusEngine{params: &Params{}}
+
+	out, herr := eng.GenerateOutput(ctx, graph, nil)
+	require.Nil(t, herr)
+	require.Equal(t, "OK\n", string(out))
+	count, err := testutil.GatherAndCount(prom.DefaultGatherer, metricName)
+	require.NoError(t, err)
+	require.Equal(t, 12, count)
+
+	// departed and moved nodes
+	graph, _ = translate.GetTreeTestSet(false)
+	delete(graph.Tiers.Vertices["S1"].Vertices, "S3")
+	graph.Domains = topology.DomainMap{"nvl1": {"Node201": {InstanceID: "I21"}}}
+
+	_, herr = eng.GenerateOutput(ctx, graph, nil)
+	require.Nil(t, herr)
+	expected := metricHead + `topograph_node_topology_info{accelerator="",block="",core="",instance="I22",leaf="S2",node="Node202",spine="S1"} 1
+topograph_node_topology_info{accelerator="",block="",core="",instance="I25",leaf="S2",node="Node205",spine="S1"} 1
+topograph_node_topology_info{accelerator="nvl1",block="block001",core="",instance="I21",leaf="S2",node="Node201",spine="S1"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(prom.DefaultGatherer, strings.NewReader(expected), metricName))
+
+	_, herr = eng.GenerateOutput(ctx, &topology.Graph{}, nil)
+	require.NotNil(t, herr)
+	require.Equal(t, http.StatusBadRequest, herr.Code())
+}
+
+func TestGetParameters(t *testing.T) {
+	p, err := getParameters(map[string]any{
+		"naming":    map[string]any{"switches": "sw{tier}-{index}", "blocks": "id"},
+		"idMapPath": "/var/lib/topograph/idmap.json",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &Params{
+		Naming:    topology.Naming{Switches: "sw{tier}-{index}", Blocks: "id"},
+		IDMapPath: "/var/lib/topograph/idmap.json",
+	}, p)
+
+	_, err = getParameters(map[string]any{"naming": map[string]any{"blocks": "{tier}"}})
+	require.EqualError(t, err, `invalid block naming: template "{tier}" must contain one of {index}, {id}, {name} or {hash}`)
+}
+
+func TestGenerateOutputNaming(t *testing.T) {
+	ctx := context.Background()
+	graph, _ := translate.GetTreeTestSet(false)
+	delete(graph.Tiers.Vertices["S1"].Vertices, "S3")
+	graph.Domains = topology.DomainMap{"nvl1": {"Node201": {InstanceID: "I21"}}}
+
+	// the names recorded by the SLURM engine
+	idMap := translate.NewIDMap()
+	idMap.Switches["S2"] = "leaf-a"
+	idMap.Blocks[""] = map[string]string{"nvl1": "block007"}
+	data, err := idMap.Marshal()
+	require.NoError(t, err)
+	idMapPath := filepath.Join(t.TempDir(), "idmap.json")
+	require.NoError(t, os.WriteFile(idMapPath, data, 0644))
+
+	eng := &PrometheusEngine{params: &Params{Naming: topology.Naming{Switches: "sw{tier}-{index}"}, IDMapPath: idMapPath}}
+	_, herr := eng.GenerateOutput(ctx, graph, nil)
+	require.Nil(t, herr)
+	expected := metricHead + `topograph_node_topology_info{accelerator="",block="",core="",instance="I22",leaf="leaf-a",node="Node202",spine="sw2-1"} 1
+topograph_node_topology_info{accelerator="",block="",core="",instance="I25",leaf="leaf-a",node="Node205",spine="sw2-1"} 1
+topograph_node_topology_info{accelerator="nvl1",block="block007",core="",instance="I21",leaf="leaf-a",node="Node201",spine="sw2-1"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(prom.DefaultGatherer, strings.NewReader(expected), metricName))
+
+	// the ID map is not written
+	read, err := os.ReadFile(idMapPath)
+	require.NoError(t, err)
+	require.Equal(t, data, read)
+}
+
+func TestGetComputeInstances(t *testing.T) {
+	eng := &PrometheusEngine{}
+	cis, herr := eng.GetComputeInstances(context.Background(), nil)
+	require.Nil(t, cis)
+	require.NotNil(t, herr)
+	require.Equal(t, http.StatusBadRequest, herr.Code())
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/NVIDIA/topograph/internal/files"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+const (
+	NAME = "report"
+
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+type ReportEngine struct {
+	params *Params
+}
+
+type Params struct {
+	// Format is one of "json" (default) or "yaml"
+	Format string `mapstructure:"format"`
+	// BlockSizes shapes the reported blocks as in the slurm engine
+	BlockSizes         []int  `mapstructure:"blockSizes"`
+	TopologyConfigPath string `mapstructure:"topologyConfigPath"`
+	// AutoBlockSizes is set by blockSizes "auto-optimal"
+	AutoBlockSizes bool `mapstructure:"-"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(_ context.Context, params engines.Config) (engines.Engine, *httperr.Error) {
+	p, err := getParameters(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return &ReportEngine{
+		params: p,
+	}, nil
+}
+
+func getParameters(params engines.Config) (*Params, error) {
+	p := &Params{}
+	auto, err := translate.DecodeParams(params, p)
+	if err != nil {
+		return nil, err
+	}
+	p.AutoBlockSizes = auto[""]
+
+	switch p.Format {
+	case "":
+		p.Format = FormatJSON
+	case FormatJSON, FormatYAML:
+		// nop
+	default:
+		return nil, fmt.Errorf("unsupported format %q", p.Format)
+	}
+
+	for i, bs := range p.BlockSizes {
+		if bs <= 0 {
+			return nil, fmt.Errorf("blockSizes[%d]=%d must be positive", i, bs)
+		}
+	}
+
+	return p, nil
+}
+
+func (eng *ReportEngine) GenerateOutput(_ context.Context, graph *topology.Graph, _ map[string]any) ([]byte, *httperr.Error) {
+	cfg := &translate.Config{BlockSizes: eng.params.BlockSizes, AutoBlockSizes: eng.params.AutoBlockSizes}
+	switch {
+	case graph != nil && graph.Tiers != nil:
+		cfg.Plugin = topology.TopologyTree
+	case graph != nil && graph.Domains != nil:
+		cfg.Plugin = topology.TopologyBlock
+	default:
+		return nil, httperr.NewError(http.StatusBadRequest, "no topology data to report")
+	}
+
+	report, err := translate.NewReport(graph, cfg)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	var data []byte
+	if eng.params.Format == FormatYAML {
+		data, err = yaml.Marshal(report)
+	} else {
+		data, err = json.MarshalIndent(report, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	if len(eng.params.TopologyConfigPath) == 0 {
+		return data, nil
+	}
+
+	if err := files.Create(eng.params.TopologyConfigPath, data); err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return []byte("OK\n"), nil
+}
+
+func (eng *ReportEngine) GetComputeInstances(_ context.Context, _ any) ([]topology.ComputeInstances, *httperr.Error) {
+	return nil, httperr.NewError(http.StatusBadRequest,
+		"report engine requires nodes in the request or a provider that can supply compute instances")
+}
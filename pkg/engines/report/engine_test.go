@@ -0,0 +1,123 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+func TestNamedLoader(t *testing.T) {
+	name, _ := NamedLoader()
+	require.Equal(t, NAME, name)
+}
+
+func TestGetParameters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]any
+		want   *Params
+		err    string
+	}{
+		{
+			name:   "Case 1: default format",
+			params: nil,
+			want:   &Params{Format: FormatJSON},
+		},
+		{
+			name:   "Case 2: valid params",
+			params: map[string]any{"format": "yaml", "blockSizes": []int{2, 4}, "topologyConfigPath": "/tmp/x"},
+			want:   &Params{Format: FormatYAML, BlockSizes: []int{2, 4}, TopologyConfigPath: "/tmp/x"},
+		},
+		{
+			name:   "Case 3: invalid format",
+			params: map[string]any{"format": "csv"},
+			err:    `unsupported format "csv"`,
+		},
+		{
+			name:   "Case 4: invalid block size",
+			params: map[string]any{"blockSizes": []int{0}},
+			err:    `blockSizes[0]=0 must be positive`,
+		},
+		{
+			name:   "Case 5: auto-optimal block sizes",
+			params: map[string]any{"blockSizes": "auto-optimal"},
+			want:   &Params{Format: FormatJSON, AutoBlockSizes: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := getParameters(tc.params)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGenerateOutput(t *testing.T) {
+	ctx := context.Background()
+	graph, _ := translate.GetTreeTestSet(false)
+
+	t.Run("json", func(t *testing.T) {
+		out, herr := (&ReportEngine{params: &Params{Format: FormatJSON}}).GenerateOutput(ctx, graph, nil)
+		require.Nil(t, herr)
+
+		var report translate.Report
+		require.NoError(t, json.Unmarshal(out, &report))
+		require.Len(t, report.Tiers, 2)
+		require.Equal(t, 2, report.Tiers[0].Switches)
+		require.Equal(t, 3, report.Tiers[0].Nodes.Max)
+		require.Equal(t, 1, report.Tiers[1].Switches)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		out, herr := (&ReportEngine{params: &Params{Format: FormatYAML}}).GenerateOutput(ctx, graph, nil)
+		require.Nil(t, herr)
+
+		var report translate.Report
+		require.NoError(t, yaml.Unmarshal(out, &report))
+		require.Len(t, report.Tiers, 2)
+	})
+
+	t.Run("block plan", func(t *testing.T) {
+		graph, _ := translate.GetBlockWithMultiIBTestSet()
+		out, herr := (&ReportEngine{params: &Params{Format: FormatJSON, AutoBlockSizes: true}}).GenerateOutput(ctx, graph, nil)
+		require.Nil(t, herr)
+
+		var report translate.Report
+		require.NoError(t, json.Unmarshal(out, &report))
+		require.Equal(t, []int{3, 6, 12}, report.BlockSizes)
+		require.Equal(t, []int{3, 6, 12}, report.BlockPlan.Recommended)
+		require.Len(t, report.BlockPlan.Candidates, 2)
+	})
+
+	t.Run("no topology", func(t *testing.T) {
+		_, herr := (&ReportEngine{params: &Params{Format: FormatJSON}}).GenerateOutput(ctx, &topology.Graph{}, nil)
+		require.NotNil(t, herr)
+		require.Equal(t, http.StatusBadRequest, herr.Code())
+	})
+}
+
+func TestGetComputeInstances(t *testing.T) {
+	eng := &ReportEngine{params: &Params{}}
+	cis, herr := eng.GetComputeInstances(context.Background(), nil)
+	require.Nil(t, cis)
+	require.NotNil(t, herr)
+	require.Equal(t, http.StatusBadRequest, herr.Code())
+}
@@ -34,7 +34,6 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 
-	"github.com/NVIDIA/topograph/internal/config"
 	"github.com/NVIDIA/topograph/internal/httperr"
 	"github.com/NVIDIA/topograph/internal/k8s"
 	"github.com/NVIDIA/topograph/pkg/engines"
@@ -95,6 +94,8 @@ type Params struct {
 	ConfigUpdateMode string `mapstructure:"configUpdateMode,omitempty"`
 	// Topologies specifies per-partition topology configuration
 	Topologies map[string]*Topology `mapstructure:"topologies,omitempty"`
+	// StableIDs keeps the switch and block names across regenerations in the ConfigMap annotation
+	StableIDs bool `mapstructure:"stableIds"`
 
 	// derived fields
 	podListOpt  *metav1.ListOptions
@@ -140,9 +141,16 @@ func Loader(_ context.Context, params engines.Config) (engines.Engine, *httperr.
 
 func getParameters(params engines.Config) (*Params, error) {
 	p := &Params{}
-	if err := config.Decode(params, p); err != nil {
+	auto, err := translate.DecodeParams(params, p)
+	if err != nil {
 		return nil, err
 	}
+	p.AutoBlockSizes = auto[""]
+	for name, topo := range p.Topologies {
+		if topo != nil {
+			topo.AutoBlockSizes = auto[name]
+		}
+	}
 
 	// Validate config update mode
 	if len(p.ConfigUpdateMode) != 0 && p.ConfigUpdateMode != ConfigUpdateModeNone && p.ConfigUpdateMode != ConfigUpdateModeSkeletonOnly {
@@ -438,11 +446,21 @@ func (eng *SlinkyEngine) GenerateOutput(ctx context.Context, graph *topology.Gra
 		}
 	}
 
+	if p.StableIDs {
+		if cfg.IDMap, err = eng.readIDMap(ctx); err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
 	nt, err := translate.NewNetworkTopology(graph, cfg)
 	if err != nil {
 		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
 	}
 
+	if len(p.Explain) != 0 {
+		return slurm.ExplainNode(nt, p.Explain)
+	}
+
 	// Get desired topology from root topology graph
 	buf := &bytes.Buffer{}
 	topologies, httpErr := nt.GenerateTopologyConfig(buf, p.ConfigUpdateMode == ConfigUpdateModeSkeletonOnly)
@@ -451,10 +469,24 @@ func (eng *SlinkyEngine) GenerateOutput(ctx context.Context, graph *topology.Gra
 	}
 	desiredTopology := buf.String()
 
+	var annotations map[string]string
+	if cfg.IDMap != nil {
+		idMap, err := cfg.IDMap.Marshal()
+		if err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+		annotations = map[string]string{topology.KeyConfigMapIDMap: string(idMap)}
+	}
+
 	// If the slurm config update mode is not none, update the slurm config
 	if p.ConfigUpdateMode != ConfigUpdateModeNone {
 		data := map[string]string{p.ConfigPath: desiredTopology}
-		if err := eng.UpdateTopologyConfigmap(ctx, p.ConfigMapName, p.Namespace, data); err != nil {
+		if err := eng.UpdateTopologyConfigmap(ctx, p.ConfigMapName, p.Namespace, data, annotations); err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+	} else if annotations != nil {
+		// keep the ID map without updating the slurm config
+		if err := eng.UpdateTopologyConfigmap(ctx, p.ConfigMapName, p.Namespace, nil, annotations); err != nil {
 			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
 		}
 	}
@@ -474,10 +506,13 @@ func (eng *SlinkyEngine) GenerateOutput(ctx context.Context, graph *topology.Gra
 	return []byte("OK\n"), nil
 }
 
-func (eng *SlinkyEngine) UpdateTopologyConfigmap(ctx context.Context, name, namespace string, data map[string]string) error {
+// UpdateTopologyConfigmap updates the data of the topology ConfigMap, along with the metadata
+// and the given annotations, or creates the ConfigMap
+func (eng *SlinkyEngine) UpdateTopologyConfigmap(ctx context.Context, name, namespace string, data, extraAnnotations map[string]string) error {
 	klog.Infof("Updating topology config %s/%s", namespace, name)
 
 	annotations := eng.generateConfigMapAnnotations()
+	maps.Copy(annotations, extraAnnotations)
 	verb := "get"
 	cmClient := eng.client.CoreV1().ConfigMaps(namespace)
 	cm, err := cmClient.Get(ctx, name, metav1.GetOptions{})
@@ -491,6 +526,12 @@ func (eng *SlinkyEngine) UpdateTopologyConfigmap(ctx context.Context, name, name
 				break
 			}
 		}
+		for key, value := range extraAnnotations {
+			if cm.Annotations[key] != value {
+				changed = true
+				break
+			}
+		}
 
 		if !changed {
 			klog.Infof("No changes to configmap %s/%s found, skipping update", namespace, name)
@@ -718,3 +759,16 @@ func intToStr(input []int) string {
 	}
 	return strings.Join(strs, ",")
 }
+
+// readIDMap reads the ID map from the topology ConfigMap annotation,
+// or returns an empty map if the ConfigMap or the annotation does not exist yet
+func (eng *SlinkyEngine) readIDMap(ctx context.Context) (*translate.IDMap, error) {
+	cm, err := eng.client.CoreV1().ConfigMaps(eng.params.Namespace).Get(ctx, eng.params.ConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return translate.NewIDMap(), nil
+		}
+		return nil, fmt.Errorf("failed to get configmap %s/%s: %v", eng.params.Namespace, eng.params.ConfigMapName, err)
+	}
+	return translate.ParseIDMap([]byte(cm.Annotations[topology.KeyConfigMapIDMap]))
+}
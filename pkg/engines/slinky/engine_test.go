@@ -524,6 +524,50 @@ BlockSizes=1
 `, cm.Data["topology.conf"])
 }
 
+func TestGenerateOutputStableIDs(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset()
+
+	eng := &SlinkyEngine{
+		client: client,
+		params: &Params{
+			BaseParams:    slurm.BaseParams{Plugin: topology.TopologyBlock},
+			Namespace:     "test-ns",
+			ConfigMapName: "slurm-config",
+			ConfigPath:    "topology.conf",
+			StableIDs:     true,
+		},
+	}
+
+	domains := topology.NewDomainMap()
+	domains.AddHost("nvl2", "i3", "n3")
+	domains.AddHost("nvl3", "i5", "n5")
+
+	_, httpErr := eng.GenerateOutput(ctx, &topology.Graph{Domains: domains}, nil)
+	require.Nil(t, httpErr)
+
+	cm, err := client.CoreV1().ConfigMaps("test-ns").Get(ctx, "slurm-config", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, `{"blocks":{"":{"nvl2":"block001","nvl3":"block002"}}}`, cm.Annotations[topology.KeyConfigMapIDMap])
+
+	// a new domain sorted first keeps the names of the existing blocks
+	domains.AddHost("nvl1", "i1", "n1")
+	_, httpErr = eng.GenerateOutput(ctx, &topology.Graph{Domains: domains}, nil)
+	require.Nil(t, httpErr)
+
+	cm, err = client.CoreV1().ConfigMaps("test-ns").Get(ctx, "slurm-config", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, `# block003=nvl1
+BlockName=block003 Nodes=n1
+# block001=nvl2
+BlockName=block001 Nodes=n3
+# block002=nvl3
+BlockName=block002 Nodes=n5
+BlockSizes=1,2
+`, cm.Data["topology.conf"])
+	require.Equal(t, `{"blocks":{"":{"nvl1":"block003","nvl2":"block001","nvl3":"block002"}}}`, cm.Annotations[topology.KeyConfigMapIDMap])
+}
+
 func TestUsesBlockTopology(t *testing.T) {
 	require.False(t, usesBlockTopology(nil))
 	require.False(t, usesBlockTopology(&translate.Config{Plugin: topology.TopologyTree}))
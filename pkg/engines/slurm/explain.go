@@ -0,0 +1,38 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+// KeyExplain is the engine parameter naming the node whose placement is explained
+const KeyExplain = "explain"
+
+// ExplainNode generates the topology config to record the block placement
+// and returns the JSON decision trail of the node instead of the config
+func ExplainNode(nt *translate.NetworkTopology, node string) ([]byte, *httperr.Error) {
+	if httpErr := nt.Generate(io.Discard); httpErr != nil {
+		return nil, httpErr
+	}
+
+	exp, httpErr := nt.Explain(node)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	data, err := json.MarshalIndent(exp, "", "  ")
+	if err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,24 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/files"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+// writeIDMap writes the ID map state file
+func writeIDMap(path string, idMap *translate.IDMap) error {
+	data, err := idMap.Marshal()
+	if err != nil {
+		return err
+	}
+
+	klog.Infof("Writing ID map in %q", path)
+	return files.Create(path, append(data, '\n'))
+}
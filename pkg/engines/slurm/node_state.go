@@ -0,0 +1,160 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/exec"
+	"github.com/NVIDIA/topograph/internal/files"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+const (
+	NodeStateActionAnnotate = "annotate"
+	NodeStateActionExclude  = "exclude"
+)
+
+// DefaultUnusableNodeStates lists the node states treated as unusable if none are specified
+var DefaultUnusableNodeStates = []string{"DOWN", "DRAIN", "FAIL", "FUTURE"}
+
+// NodeStateParams configures the handling of the nodes unavailable for jobs
+type NodeStateParams struct {
+	// States lists the node states, any of which makes a node unusable
+	States []string `mapstructure:"states"`
+	// Action is either "annotate" (default) or "exclude"
+	Action string `mapstructure:"action"`
+	// ReportPath is the file for the report of the domains with less usable nodes than the base block size
+	ReportPath string `mapstructure:"reportPath"`
+}
+
+func (p *NodeStateParams) validate() error {
+	switch p.Action {
+	case "":
+		p.Action = NodeStateActionAnnotate
+	case NodeStateActionAnnotate, NodeStateActionExclude:
+	default:
+		return fmt.Errorf("unsupported nodeStates action %q", p.Action)
+	}
+
+	states := p.States
+	if len(states) == 0 {
+		states = DefaultUnusableNodeStates
+	}
+	p.States = make([]string, 0, len(states))
+	for _, state := range states {
+		p.States = append(p.States, strings.ToUpper(state))
+	}
+
+	return nil
+}
+
+// GetNodeInfo returns the Slurm nodes with their states and partitions reported by scontrol
+func GetNodeInfo(ctx context.Context) ([]NodeInfo, error) {
+	stdout, err := exec.Exec(ctx, "scontrol", []string{"show", "nodes", "-o"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(4).Infof("stdout: %s", stdout.String())
+
+	return parseNodeInfo(stdout.String())
+}
+
+func parseNodeInfo(data string) ([]NodeInfo, error) {
+	nodes := []NodeInfo{}
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		var node *NodeInfo
+		for _, field := range strings.Fields(scanner.Text()) {
+			key, val, _ := strings.Cut(field, "=")
+			switch key {
+			case "NodeName":
+				node = &NodeInfo{Name: val}
+			case "State":
+				if node != nil {
+					node.State = parseNodeState(val)
+				}
+			case "Partitions":
+				if node != nil {
+					node.Partitions = strings.Split(val, ",")
+				}
+			}
+		}
+		if node != nil {
+			nodes = append(nodes, *node)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed scan output: %v", err)
+	}
+
+	return nodes, nil
+}
+
+// parseNodeState splits the scontrol node state, e.g. "IDLE+DRAIN" or "DOWN*",
+// into the base state and flags
+func parseNodeState(state string) []string {
+	states := []string{}
+	for _, s := range strings.Split(state, "+") {
+		// drop the state suffixes, e.g. "*" for not responding nodes
+		s = strings.TrimRight(s, "*~#!%$@^-")
+		if len(s) != 0 {
+			states = append(states, s)
+		}
+	}
+	return states
+}
+
+// getUnusableNodes returns the map of the nodes in any of the given states to their full states
+func getUnusableNodes(nodes []NodeInfo, states []string) map[string]string {
+	unusable := make(map[string]string)
+	for _, node := range nodes {
+		for _, state := range node.State {
+			if slices.Contains(states, strings.ToUpper(state)) {
+				unusable[node.Name] = strings.Join(node.State, "+")
+				break
+			}
+		}
+	}
+	return unusable
+}
+
+// setUnusableNodes queries the node states and marks the unusable nodes in the translation config
+func setUnusableNodes(ctx context.Context, c *restdClient, p *NodeStateParams, cfg *translate.Config) error {
+	nodes, err := getNodes(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	cfg.UnusableNodes = getUnusableNodes(nodes, p.States)
+	cfg.ExcludeUnusableNodes = p.Action == NodeStateActionExclude
+	klog.InfoS("Detected unusable nodes", "count", len(cfg.UnusableNodes), "action", p.Action)
+
+	return nil
+}
+
+// writeDegradedDomainsReport writes the JSON report of the domains with less usable nodes than the base block size
+func writeDegradedDomainsReport(path string, degraded []*translate.DegradedDomain) error {
+	if degraded == nil {
+		degraded = []*translate.DegradedDomain{}
+	}
+	data, err := json.MarshalIndent(degraded, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	klog.Infof("Writing degraded domains report in %q", path)
+	return files.Create(path, append(data, '\n'))
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestParseNodeInfo(t *testing.T) {
+	data := `NodeName=node1 Arch=x86_64 CoresPerSocket=8 State=IDLE Partitions=gpu
+NodeName=node2 Arch=x86_64 State=DOWN*+DRAIN Partitions=gpu,debug
+NodeName=node3 State=FUTURE
+`
+	nodes, err := parseNodeInfo(data)
+	require.NoError(t, err)
+	require.Equal(t, []NodeInfo{
+		{Name: "node1", State: []string{"IDLE"}, Partitions: []string{"gpu"}},
+		{Name: "node2", State: []string{"DOWN", "DRAIN"}, Partitions: []string{"gpu", "debug"}},
+		{Name: "node3", State: []string{"FUTURE"}},
+	}, nodes)
+
+	require.Equal(t, map[string]string{
+		"node2": "DOWN+DRAIN",
+		"node3": "FUTURE",
+	}, getUnusableNodes(nodes, DefaultUnusableNodeStates))
+
+	require.Equal(t, map[string]string{
+		"node2": "DOWN+DRAIN",
+	}, getUnusableNodes(nodes, []string{"DRAIN"}))
+}
+
+func TestNodeStateParams(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params *NodeStateParams
+		valid  *NodeStateParams
+		err    string
+	}{
+		{
+			name:   "Case 1: defaults",
+			params: &NodeStateParams{},
+			valid: &NodeStateParams{
+				States: DefaultUnusableNodeStates,
+				Action: NodeStateActionAnnotate,
+			},
+		},
+		{
+			name: "Case 2: explicit states",
+			params: &NodeStateParams{
+				States: []string{"down", "Drain"},
+				Action: NodeStateActionExclude,
+			},
+			valid: &NodeStateParams{
+				States: []string{"DOWN", "DRAIN"},
+				Action: NodeStateActionExclude,
+			},
+		},
+		{
+			name:   "Case 3: bad action",
+			params: &NodeStateParams{Action: "remove"},
+			err:    `unsupported nodeStates action "remove"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.params.validate()
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.valid, tc.params)
+			}
+		})
+	}
+}
+
+func TestGenerateOutputNodeStates(t *testing.T) {
+	ctx := context.Background()
+	setScontrol(t,
+		"NodeName=n1 State=IDLE",
+		"NodeName=n2 State=DOWN*",
+		"NodeName=n3 State=IDLE",
+		"NodeName=n4 State=MIXED+DRAIN",
+	)
+
+	graph := &topology.Graph{Domains: topology.NewDomainMap()}
+	graph.Domains.AddHost("nvl1", "i1", "n1")
+	graph.Domains.AddHost("nvl1", "i2", "n2")
+	graph.Domains.AddHost("nvl2", "i3", "n3")
+	graph.Domains.AddHost("nvl2", "i4", "n4")
+
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	params := map[string]any{
+		"plugin":     topology.TopologyBlock,
+		"blockSizes": []int{2},
+		"nodeStates": map[string]any{
+			"states":     []string{"down"},
+			"action":     NodeStateActionExclude,
+			"reportPath": reportPath,
+		},
+	}
+
+	data, httpErr := GenerateOutput(ctx, graph, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, `# block001=nvl1
+BlockName=block001 Nodes=n1
+# block002=nvl2
+BlockName=block002 Nodes=n[3-4]
+BlockSizes=2
+`, string(data))
+
+	report, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"domain":"nvl1","nodes":2,"usableNodes":1,"baseBlockSize":2,"unusableNodes":{"n2":"DOWN"}}]`, string(report))
+
+	params["nodeStates"] = map[string]any{"action": "remove"}
+	_, httpErr = GenerateOutput(ctx, graph, params)
+	require.NotNil(t, httpErr)
+	require.Equal(t, `unsupported nodeStates action "remove"`, httpErr.Error())
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/httpreq"
+)
+
+const (
+	DefaultRestdAPIVersion = "v0.0.40"
+
+	// EnvSlurmJWT is the environment variable holding the slurmrestd JWT, as used by the Slurm clients
+	EnvSlurmJWT = "SLURM_JWT"
+
+	headerUserToken = "X-SLURM-USER-TOKEN"
+	headerUserName  = "X-SLURM-USER-NAME"
+)
+
+// RestdParams configures the Slurm REST API (slurmrestd) backend
+type RestdParams struct {
+	// URL is the base URL of slurmrestd, e.g. http://slurmctld:6820
+	URL string `mapstructure:"url"`
+	// TokenFile is the path of the file holding the JWT, e.g., a mounted Secret;
+	// the JWT defaults to the SLURM_JWT environment variable
+	TokenFile          string `mapstructure:"tokenFile"`
+	User               string `mapstructure:"user"`
+	APIVersion         string `mapstructure:"apiVersion"`
+	InsecureSkipVerify bool   `mapstructure:"insecureSkipVerify"`
+
+	// Token is the JWT read from TokenFile or SLURM_JWT; it is not a request parameter,
+	// as the request parameters are logged
+	Token string `mapstructure:"-"`
+}
+
+// NodeInfo describes a Slurm node reported by slurmrestd
+type NodeInfo struct {
+	Name       string   `json:"name"`
+	State      []string `json:"state"`
+	Partitions []string `json:"partitions"`
+}
+
+type restdError struct {
+	Error       string `json:"error"`
+	Description string `json:"description"`
+}
+
+type restdNodes struct {
+	Nodes  []NodeInfo   `json:"nodes"`
+	Errors []restdError `json:"errors"`
+}
+
+type restdPartitions struct {
+	Partitions []struct {
+		Name  string `json:"name"`
+		Nodes struct {
+			Configured string `json:"configured"`
+		} `json:"nodes"`
+	} `json:"partitions"`
+	Errors []restdError `json:"errors"`
+}
+
+type restdReply struct {
+	Errors []restdError `json:"errors"`
+}
+
+type restdClient struct {
+	params *RestdParams
+}
+
+func newRestdClient(p *RestdParams) (*restdClient, error) {
+	if len(p.URL) == 0 {
+		return nil, fmt.Errorf("must specify slurmrestd url")
+	}
+
+	params := *p
+	if len(params.Token) == 0 && len(params.TokenFile) != 0 {
+		data, err := os.ReadFile(params.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read slurmrestd token: %v", err)
+		}
+		params.Token = strings.TrimSpace(string(data))
+	}
+	if len(params.Token) == 0 {
+		params.Token = os.Getenv(EnvSlurmJWT)
+	}
+	if len(params.Token) == 0 {
+		return nil, fmt.Errorf("must specify slurmrestd tokenFile or set %s", EnvSlurmJWT)
+	}
+	if len(params.APIVersion) == 0 {
+		params.APIVersion = DefaultRestdAPIVersion
+	}
+
+	return &restdClient{params: &params}, nil
+}
+
+// getRestdClient returns the slurmrestd client, or nil if the backend is not configured
+func getRestdClient(p *Params) (*restdClient, error) {
+	if p.Restd == nil {
+		return nil, nil
+	}
+	return newRestdClient(p.Restd)
+}
+
+func (c *restdClient) get(ctx context.Context, reply any, paths ...string) error {
+	headers := map[string]string{headerUserToken: c.params.Token}
+	if len(c.params.User) != 0 {
+		headers[headerUserName] = c.params.User
+	}
+	paths = append([]string{"slurm", c.params.APIVersion}, paths...)
+	f := httpreq.GetRequestFunc(ctx, http.MethodGet, headers, nil, nil, c.params.URL, paths...)
+
+	body, httpErr := httpreq.DoRequestWithRetries(f, c.params.InsecureSkipVerify)
+	if httpErr != nil {
+		return fmt.Errorf("slurmrestd request failed with status %d: %s", httpErr.Code(), httpErr.Error())
+	}
+	klog.V(4).Infof("slurmrestd reply: %s", string(body))
+
+	if err := json.Unmarshal(body, reply); err != nil {
+		return fmt.Errorf("failed to parse slurmrestd reply: %v", err)
+	}
+
+	return nil
+}
+
+// GetNodes returns the Slurm nodes with their states and partitions
+func (c *restdClient) GetNodes(ctx context.Context) ([]NodeInfo, error) {
+	var reply restdNodes
+	if err := c.get(ctx, &reply, "nodes"); err != nil {
+		return nil, err
+	}
+	if err := restdErrors(reply.Errors); err != nil {
+		return nil, err
+	}
+	return reply.Nodes, nil
+}
+
+// GetPartitionNodes returns the node list of the partition in the "scontrol show partition" format
+func (c *restdClient) GetPartitionNodes(ctx context.Context, partition string, _ []any) (string, error) {
+	var reply restdPartitions
+	if err := c.get(ctx, &reply, "partition", partition); err != nil {
+		return "", err
+	}
+	if err := restdErrors(reply.Errors); err != nil {
+		return "", err
+	}
+	for _, p := range reply.Partitions {
+		if p.Name != partition {
+			continue
+		}
+		nodes := p.Nodes.Configured
+		if len(nodes) == 0 {
+			nodes = "NONE"
+		}
+		return fmt.Sprintf("PartitionName=%s Nodes=%s", p.Name, nodes), nil
+	}
+	return "", fmt.Errorf("partition %q not found", partition)
+}
+
+// Reconfigure instructs slurmctld to re-read the configuration files
+func (c *restdClient) Reconfigure(ctx context.Context) error {
+	var reply restdReply
+	if err := c.get(ctx, &reply, "reconfigure"); err != nil {
+		return err
+	}
+	return restdErrors(reply.Errors)
+}
+
+func restdErrors(errs []restdError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		if len(e.Description) != 0 {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", e.Error, e.Description))
+		} else {
+			msgs = append(msgs, e.Error)
+		}
+	}
+	return fmt.Errorf("slurmrestd error: %s", strings.Join(msgs, "; "))
+}
+
+// withFallback runs the slurmrestd request, if configured, and falls back to scontrol on failure
+func withFallback[T any](ctx context.Context, c *restdClient, restd func(context.Context, *restdClient) (T, error), scontrol func(context.Context) (T, error)) (T, error) {
+	if c == nil {
+		return scontrol(ctx)
+	}
+
+	res, err := restd(ctx, c)
+	if err == nil {
+		return res, nil
+	}
+	klog.Warningf("slurmrestd request failed, falling back to scontrol: %v", err)
+
+	res, scErr := scontrol(ctx)
+	if scErr != nil {
+		return res, fmt.Errorf("%v; scontrol fallback failed: %v", err, scErr)
+	}
+	return res, nil
+}
+
+func getNodes(ctx context.Context, c *restdClient) ([]NodeInfo, error) {
+	return withFallback(ctx, c,
+		func(ctx context.Context, c *restdClient) ([]NodeInfo, error) {
+			return c.GetNodes(ctx)
+		},
+		GetNodeInfo)
+}
+
+func getNodeList(ctx context.Context, c *restdClient) ([]string, error) {
+	nodes, err := getNodes(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return nodeNames(nodes), nil
+}
+
+func getTopologyNodeFinder(c *restdClient) *TopologyNodeFinder {
+	return &TopologyNodeFinder{
+		GetPartitionNodes: func(ctx context.Context, partition string, params []any) (string, error) {
+			return withFallback(ctx, c,
+				func(ctx context.Context, c *restdClient) (string, error) {
+					return c.GetPartitionNodes(ctx, partition, params)
+				},
+				func(ctx context.Context) (string, error) {
+					return getPartitionNodes(ctx, partition, params)
+				})
+		},
+	}
+}
+
+func runReconfigure(ctx context.Context, c *restdClient) error {
+	_, err := withFallback(ctx, c,
+		func(ctx context.Context, c *restdClient) (struct{}, error) {
+			return struct{}{}, c.Reconfigure(ctx)
+		},
+		func(ctx context.Context) (struct{}, error) {
+			return struct{}{}, reconfigure(ctx)
+		})
+	return err
+}
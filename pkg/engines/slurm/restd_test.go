@@ -0,0 +1,257 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package slurm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	restdNodesReply = `{
+  "nodes": [
+    {"name": "node1", "state": ["IDLE"], "partitions": ["gpu"]},
+    {"name": "node2", "state": ["DOWN", "DRAIN"], "partitions": ["gpu", "debug"]}
+  ],
+  "errors": []
+}`
+
+	restdPartitionReply = `{
+  "partitions": [
+    {"name": "gpu", "nodes": {"configured": "node[1-2]"}}
+  ]
+}`
+
+	restdEmptyPartitionReply = `{
+  "partitions": [
+    {"name": "empty", "nodes": {"configured": ""}}
+  ]
+}`
+
+	restdErrorReply = `{
+  "errors": [
+    {"error": "Unable to query partition", "description": "invalid partition"}
+  ]
+}`
+)
+
+type restdStub struct {
+	reconfigured bool
+}
+
+func (s *restdStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(headerUserToken) != "token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/slurm/v0.0.40/nodes":
+		_, _ = w.Write([]byte(restdNodesReply))
+	case "/slurm/v0.0.40/partition/gpu":
+		_, _ = w.Write([]byte(restdPartitionReply))
+	case "/slurm/v0.0.40/partition/empty":
+		_, _ = w.Write([]byte(restdEmptyPartitionReply))
+	case "/slurm/v0.0.40/partition/bad":
+		_, _ = w.Write([]byte(restdErrorReply))
+	case "/slurm/v0.0.40/reconfigure":
+		s.reconfigured = true
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// setScontrol installs a fake scontrol printing the given lines, or failing if there are none
+func setScontrol(t *testing.T, lines ...string) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\n"
+	for _, line := range lines {
+		script += "echo '" + line + "'\n"
+	}
+	if len(lines) == 0 {
+		script += "exit 1\n"
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "scontrol"), []byte(script), 0755))
+	t.Setenv("PATH", dir)
+}
+
+func TestNewRestdClient(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params *RestdParams
+		env    string
+		client *restdClient
+		err    string
+	}{
+		{
+			name:   "Case 1: missing url",
+			params: &RestdParams{Token: "token"},
+			err:    "must specify slurmrestd url",
+		},
+		{
+			name:   "Case 2: missing token",
+			params: &RestdParams{URL: "http://slurmctld:6820"},
+			err:    "must specify slurmrestd tokenFile or set SLURM_JWT",
+		},
+		{
+			name:   "Case 3: missing token file",
+			params: &RestdParams{URL: "http://slurmctld:6820", TokenFile: "/nonexistent/token"},
+			err:    "failed to read slurmrestd token: open /nonexistent/token: no such file or directory",
+		},
+		{
+			name:   "Case 4: token from environment",
+			params: &RestdParams{URL: "http://slurmctld:6820"},
+			env:    "jwt",
+			client: &restdClient{params: &RestdParams{
+				URL:        "http://slurmctld:6820",
+				Token:      "jwt",
+				APIVersion: DefaultRestdAPIVersion,
+			}},
+		},
+		{
+			name: "Case 5: explicit parameters",
+			params: &RestdParams{
+				URL:        "https://slurmctld:6820",
+				Token:      "token",
+				User:       "slurm",
+				APIVersion: "v0.0.41",
+			},
+			env: "jwt",
+			client: &restdClient{params: &RestdParams{
+				URL:        "https://slurmctld:6820",
+				Token:      "token",
+				User:       "slurm",
+				APIVersion: "v0.0.41",
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(EnvSlurmJWT, tc.env)
+			client, err := newRestdClient(tc.params)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.client, client)
+			}
+		})
+	}
+}
+
+func TestRestdClient(t *testing.T) {
+	ctx := context.Background()
+	stub := &restdStub{}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	c, err := newRestdClient(&RestdParams{URL: srv.URL, Token: "token"})
+	require.NoError(t, err)
+
+	nodes, err := c.GetNodes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []NodeInfo{
+		{Name: "node1", State: []string{"IDLE"}, Partitions: []string{"gpu"}},
+		{Name: "node2", State: []string{"DOWN", "DRAIN"}, Partitions: []string{"gpu", "debug"}},
+	}, nodes)
+
+	f := getTopologyNodeFinder(c)
+
+	partNodes, err := GetPartitionNodes(ctx, "gpu", f)
+	require.NoError(t, err)
+	require.Equal(t, []string{"node[1-2]"}, partNodes)
+
+	partNodes, err = GetPartitionNodes(ctx, "empty", f)
+	require.NoError(t, err)
+	require.Empty(t, partNodes)
+
+	_, err = c.GetPartitionNodes(ctx, "bad", nil)
+	require.EqualError(t, err, "slurmrestd error: Unable to query partition: invalid partition")
+
+	require.NoError(t, runReconfigure(ctx, c))
+	require.True(t, stub.reconfigured)
+
+	c, err = newRestdClient(&RestdParams{URL: srv.URL, Token: "wrong"})
+	require.NoError(t, err)
+	_, err = c.GetNodes(ctx)
+	require.EqualError(t, err, "slurmrestd request failed with status 401: ")
+}
+
+func TestRestdFallback(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(&restdStub{})
+	defer srv.Close()
+
+	c, err := newRestdClient(&RestdParams{URL: srv.URL, Token: "wrong"})
+	require.NoError(t, err)
+
+	setScontrol(t, "NodeName=node3 Arch=x86_64", "NodeName=node4 Arch=x86_64")
+	nodes, err := getNodeList(ctx, c)
+	require.NoError(t, err)
+	require.Equal(t, []string{"node3", "node4"}, nodes)
+
+	setScontrol(t)
+	_, err = getNodeList(ctx, c)
+	require.ErrorContains(t, err, "slurmrestd request failed with status 401: ; scontrol fallback failed:")
+}
+
+func TestLoaderRestd(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(&restdStub{})
+	defer srv.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token\n"), 0600))
+
+	_, httpErr := Loader(ctx, map[string]any{"slurmrestd": map[string]any{"tokenFile": tokenFile}})
+	require.NotNil(t, httpErr)
+	require.Equal(t, http.StatusBadRequest, httpErr.Code())
+	require.Equal(t, "must specify slurmrestd url", httpErr.Error())
+
+	// the token is not a request parameter
+	t.Setenv(EnvSlurmJWT, "")
+	_, httpErr = Loader(ctx, map[string]any{"slurmrestd": map[string]any{"url": srv.URL, "token": "token"}})
+	require.NotNil(t, httpErr)
+	require.Equal(t, "must specify slurmrestd tokenFile or set SLURM_JWT", httpErr.Error())
+
+	eng, httpErr := Loader(ctx, map[string]any{"slurmrestd": map[string]any{"url": srv.URL, "tokenFile": tokenFile}})
+	require.Nil(t, httpErr)
+
+	cis, httpErr := eng.GetComputeInstances(ctx, &testMapper{})
+	require.Nil(t, httpErr)
+	require.Equal(t, []topology.ComputeInstances{
+		{Region: "region", Instances: map[string]string{"i1": "node1", "i2": "node2"}},
+	}, cis)
+}
+
+type testMapper struct{}
+
+func (m *testMapper) Instances2NodeMap(_ context.Context, nodes []string) (map[string]string, error) {
+	i2n := make(map[string]string)
+	for i, node := range nodes {
+		i2n[fmt.Sprintf("i%d", i+1)] = node
+	}
+	return i2n, nil
+}
+
+func (m *testMapper) GetInstancesRegions(_ context.Context, nodes []string) (map[string]string, error) {
+	regions := make(map[string]string)
+	for _, node := range nodes {
+		regions[node] = "region"
+	}
+	return regions, nil
+}
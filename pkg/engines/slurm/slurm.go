@@ -17,18 +17,15 @@
 package slurm
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"k8s.io/klog/v2"
 
 	"github.com/NVIDIA/topograph/internal/cluset"
-	"github.com/NVIDIA/topograph/internal/config"
 	"github.com/NVIDIA/topograph/internal/exec"
 	"github.com/NVIDIA/topograph/internal/files"
 	"github.com/NVIDIA/topograph/internal/httperr"
@@ -46,11 +43,20 @@ const TopologyHeader = `
 
 const NAME = "slurm"
 
-type SlurmEngine struct{}
+type SlurmEngine struct {
+	params *Params
+	restd  *restdClient
+}
 
 type BaseParams struct {
 	Plugin     string `mapstructure:"plugin"`
 	BlockSizes []int  `mapstructure:"blockSizes"`
+	// AutoBlockSizes is set by blockSizes "auto-optimal"
+	AutoBlockSizes bool `mapstructure:"-"`
+	// Explain is the node whose placement decision trail is returned instead of the topology config
+	Explain string `mapstructure:"explain"`
+	// Naming is the naming strategies of the switches and blocks
+	Naming topology.Naming `mapstructure:"naming"`
 }
 
 type Topology struct {
@@ -59,6 +65,10 @@ type Topology struct {
 	BlockSizes []int    `mapstructure:"blockSizes"`
 	Nodes      []string `mapstructure:"nodes"`
 	Default    bool     `mapstructure:"clusterDefault"`
+	TrimTiers  int      `mapstructure:"trimTiers"`
+	LeafOnly   bool     `mapstructure:"leafOnly"`
+	// AutoBlockSizes is set by blockSizes "auto-optimal"
+	AutoBlockSizes bool `mapstructure:"-"`
 }
 
 type Params struct {
@@ -66,6 +76,10 @@ type Params struct {
 	Topologies     map[string]*Topology `mapstructure:"topologies,omitempty"`
 	TopoConfigPath string               `mapstructure:"topologyConfigPath"`
 	Reconfigure    bool                 `mapstructure:"reconfigure"`
+	Restd          *RestdParams         `mapstructure:"slurmrestd"`
+	NodeStates     *NodeStateParams     `mapstructure:"nodeStates"`
+	// IDMapPath is the state file of the switch and block names kept across regenerations
+	IDMapPath string `mapstructure:"idMapPath"`
 }
 
 type TopologyNodeFinder struct {
@@ -92,8 +106,18 @@ func NamedLoader() (string, engines.Loader) {
 	return NAME, Loader
 }
 
-func Loader(_ context.Context, _ engines.Config) (engines.Engine, *httperr.Error) {
-	return &SlurmEngine{}, nil
+func Loader(_ context.Context, config engines.Config) (engines.Engine, *httperr.Error) {
+	p, err := getParams(config)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	c, err := getRestdClient(p)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return &SlurmEngine{params: p, restd: c}, nil
 }
 
 func (eng *SlurmEngine) GetComputeInstances(ctx context.Context, environment any) ([]topology.ComputeInstances, *httperr.Error) {
@@ -102,7 +126,7 @@ func (eng *SlurmEngine) GetComputeInstances(ctx context.Context, environment any
 		return nil, httperr.NewError(http.StatusBadRequest, "environment must implement instanceMapper")
 	}
 
-	nodes, err := GetNodeList(ctx)
+	nodes, err := getNodeList(ctx, eng.restd)
 	if err != nil {
 		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
 	}
@@ -154,30 +178,19 @@ func aggregateComputeInstances(i2n, nodeRegions map[string]string) []topology.Co
 }
 
 func GetNodeList(ctx context.Context) ([]string, error) {
-	stdout, err := exec.Exec(ctx, "scontrol", []string{"show", "nodes", "-o"}, nil)
+	nodes, err := GetNodeInfo(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return nodeNames(nodes), nil
+}
 
-	klog.V(4).Infof("stdout: %s", stdout.String())
-
-	nodes := []string{}
-	scanner := bufio.NewScanner(strings.NewReader(stdout.String()))
-	for scanner.Scan() {
-		arr := strings.Split(scanner.Text(), " ")
-		if len(arr) > 0 {
-			line := arr[0]
-			if strings.HasPrefix(line, "NodeName=") {
-				nodes = append(nodes, line[9:])
-			}
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("failed scan output: %v", err)
+func nodeNames(nodes []NodeInfo) []string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.Name)
 	}
-
-	return nodes, nil
+	return names
 }
 
 func getPartitionNodes(ctx context.Context, partition string, _ []any) (string, error) {
@@ -215,8 +228,8 @@ func parsePartitionNodes(partition string, data string) ([]string, error) {
 	return nil, fmt.Errorf("partition %q has no nodes", partition)
 }
 
-func (eng *SlurmEngine) GenerateOutput(ctx context.Context, graph *topology.Graph, params map[string]any) ([]byte, *httperr.Error) {
-	return GenerateOutput(ctx, graph, params)
+func (eng *SlurmEngine) GenerateOutput(ctx context.Context, graph *topology.Graph, _ map[string]any) ([]byte, *httperr.Error) {
+	return generateOutput(ctx, graph, eng.params, eng.restd)
 }
 
 func GenerateOutput(ctx context.Context, graph *topology.Graph, params map[string]any) ([]byte, *httperr.Error) {
@@ -229,21 +242,57 @@ func GenerateOutput(ctx context.Context, graph *topology.Graph, params map[strin
 }
 
 func GenerateOutputParams(ctx context.Context, graph *topology.Graph, params *Params) ([]byte, *httperr.Error) {
+	c, err := getRestdClient(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return generateOutput(ctx, graph, params, c)
+}
+
+// generateOutput generates the topology config with the slurmrestd client, nil for scontrol
+func generateOutput(ctx context.Context, graph *topology.Graph, params *Params, c *restdClient) ([]byte, *httperr.Error) {
 	// apply legacy default plugin value
 	if len(params.Plugin) == 0 && len(params.Topologies) == 0 {
 		params.Plugin = topology.TopologyTree
 	}
 
-	cfg, httpErr := GetTranslateConfig(ctx, &params.BaseParams, params.Topologies, &TopologyNodeFinder{GetPartitionNodes: getPartitionNodes})
+	var err error
+	cfg, httpErr := GetTranslateConfig(ctx, &params.BaseParams, params.Topologies, getTopologyNodeFinder(c))
 	if httpErr != nil {
 		return nil, httpErr
 	}
 
+	if params.NodeStates != nil {
+		if err = params.NodeStates.validate(); err != nil {
+			return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+		}
+		if err = setUnusableNodes(ctx, c, params.NodeStates, cfg); err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	if len(params.IDMapPath) != 0 {
+		if cfg.IDMap, err = translate.ReadIDMap(params.IDMapPath); err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
 	nt, err := translate.NewNetworkTopology(graph, cfg)
 	if err != nil {
 		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
 	}
 
+	if len(params.Explain) != 0 {
+		return ExplainNode(nt, params.Explain)
+	}
+
+	if params.NodeStates != nil && len(params.NodeStates.ReportPath) != 0 {
+		if err = writeDegradedDomainsReport(params.NodeStates.ReportPath, nt.GetDegradedDomains()); err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
 	path := params.TopoConfigPath
 	buf := &bytes.Buffer{}
 	if len(path) != 0 {
@@ -267,8 +316,14 @@ func GenerateOutputParams(ctx context.Context, graph *topology.Graph, params *Pa
 	if err = files.Create(path, data); err != nil {
 		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
 	}
+	// the names are only recorded once they are in use by the written topology config
+	if cfg.IDMap != nil {
+		if err = writeIDMap(params.IDMapPath, cfg.IDMap); err != nil {
+			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+		}
+	}
 	if params.Reconfigure {
-		if err = reconfigure(ctx); err != nil {
+		if err = runReconfigure(ctx, c); err != nil {
 			return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
 		}
 	}
@@ -280,10 +335,16 @@ func GetTranslateConfig(ctx context.Context, params *BaseParams, topologies map[
 	if err := validateBlockSizes(params.BlockSizes); err != nil {
 		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
 	}
+	if err := params.Naming.Validate(); err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
 
 	cfg := &translate.Config{
-		Plugin:     params.Plugin,
-		BlockSizes: params.BlockSizes,
+		Plugin:         params.Plugin,
+		BlockSizes:     params.BlockSizes,
+		AutoBlockSizes: params.AutoBlockSizes,
+		Explain:        len(params.Explain) != 0,
+		Naming:         params.Naming,
 	}
 
 	// set per-partition topologies
@@ -293,10 +354,16 @@ func GetTranslateConfig(ctx context.Context, params *BaseParams, topologies map[
 			if err := validateBlockSizes(sect.BlockSizes); err != nil {
 				return nil, httperr.NewError(http.StatusBadRequest, fmt.Sprintf("topology %q: %v", topo, err))
 			}
+			if err := validateTreeTrimming(sect); err != nil {
+				return nil, httperr.NewError(http.StatusBadRequest, fmt.Sprintf("topology %q: %v", topo, err))
+			}
 			spec := &translate.TopologySpec{
 				Plugin:         sect.Plugin,
 				BlockSizes:     sect.BlockSizes,
+				AutoBlockSizes: sect.AutoBlockSizes,
 				ClusterDefault: sect.Default,
+				TrimTiers:      sect.TrimTiers,
+				LeafOnly:       sect.LeafOnly,
 			}
 			klog.InfoS("Adding partition topology", "name", topo, "plugin", sect.Plugin, "default", sect.Default, "partition", sect.Partition)
 			if sect.Nodes != nil {
@@ -319,6 +386,16 @@ func GetTranslateConfig(ctx context.Context, params *BaseParams, topologies map[
 	return cfg, nil
 }
 
+func validateTreeTrimming(topo *Topology) error {
+	if topo.TrimTiers < 0 {
+		return fmt.Errorf("trimTiers=%d must not be negative", topo.TrimTiers)
+	}
+	if (topo.TrimTiers != 0 || topo.LeafOnly) && topo.Plugin != topology.TopologyTree {
+		return fmt.Errorf("trimTiers and leafOnly require plugin %s", topology.TopologyTree)
+	}
+	return nil
+}
+
 func validateBlockSizes(blockSizes []int) error {
 	if len(blockSizes) == 0 {
 		return nil
@@ -349,8 +426,18 @@ func validateBlockSizes(blockSizes []int) error {
 
 func getParams(params map[string]any) (*Params, error) {
 	var p Params
-	err := config.Decode(params, &p)
-	return &p, err
+	auto, err := translate.DecodeParams(params, &p)
+	if err != nil {
+		return &p, err
+	}
+
+	p.AutoBlockSizes = auto[""]
+	for name, topo := range p.Topologies {
+		if topo != nil {
+			topo.AutoBlockSizes = auto[name]
+		}
+	}
+	return &p, nil
 }
 
 func reconfigure(ctx context.Context) error {
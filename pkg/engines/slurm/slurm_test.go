@@ -20,7 +20,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -201,6 +204,41 @@ func TestGetParams(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Case3: auto-optimal block sizes",
+			in: `
+{
+  "plugin": "topology/block",
+  "blockSizes": "auto-optimal",
+  "topologies": {
+	"topo1": {
+	  "plugin": "topology/block",
+	  "blockSizes": "auto-optimal"
+	},
+	"topo2": {
+	  "plugin": "topology/block",
+	  "blockSizes": [8,16]
+	}
+  }
+}
+`,
+			params: &Params{
+				BaseParams: BaseParams{
+					Plugin:         "topology/block",
+					AutoBlockSizes: true,
+				},
+				Topologies: map[string]*Topology{
+					"topo1": {
+						Plugin:         "topology/block",
+						AutoBlockSizes: true,
+					},
+					"topo2": {
+						Plugin:     "topology/block",
+						BlockSizes: []int{8, 16},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -343,6 +381,79 @@ func TestGetTranslateConfig(t *testing.T) {
 			},
 			err: `topology "topo": blockSizes[1]=6 must be a power-of-two multiple of blockSizes[0]=2`,
 		},
+		{
+			name:   "Case 9: partition tree trimming",
+			params: &BaseParams{},
+			topologies: map[string]*Topology{
+				"training": {
+					Plugin:    topology.TopologyTree,
+					Nodes:     []string{"node[001-064]"},
+					TrimTiers: 1,
+				},
+				"inference": {
+					Plugin:   topology.TopologyTree,
+					Nodes:    []string{"node[065-100]"},
+					LeafOnly: true,
+				},
+			},
+			cfg: &translate.Config{
+				Topologies: map[string]*translate.TopologySpec{
+					"training": {
+						Plugin:    topology.TopologyTree,
+						Nodes:     []string{"node[001-064]"},
+						TrimTiers: 1,
+					},
+					"inference": {
+						Plugin:   topology.TopologyTree,
+						Nodes:    []string{"node[065-100]"},
+						LeafOnly: true,
+					},
+				},
+			},
+		},
+		{
+			name:   "Case 10: negative trimTiers",
+			params: &BaseParams{},
+			topologies: map[string]*Topology{
+				"topo": {
+					Plugin:    topology.TopologyTree,
+					Nodes:     []string{"node[001-100]"},
+					TrimTiers: -1,
+				},
+			},
+			err: `topology "topo": trimTiers=-1 must not be negative`,
+		},
+		{
+			name:   "Case 11: leafOnly with block topology",
+			params: &BaseParams{},
+			topologies: map[string]*Topology{
+				"topo": {
+					Plugin:   topology.TopologyBlock,
+					Nodes:    []string{"node[001-100]"},
+					LeafOnly: true,
+				},
+			},
+			err: `topology "topo": trimTiers and leafOnly require plugin topology/tree`,
+		},
+		{
+			name: "Case 12: naming strategies",
+			params: &BaseParams{
+				Plugin: topology.TopologyBlock,
+				Naming: topology.Naming{Switches: "{tier}-{index}", Blocks: topology.NamingID},
+			},
+			cfg: &translate.Config{
+				Plugin: topology.TopologyBlock,
+				Naming: topology.Naming{Switches: "{tier}-{index}", Blocks: topology.NamingID},
+			},
+		},
+		{
+			name: "Case 13: invalid block naming",
+			params: &BaseParams{
+				Plugin: topology.TopologyBlock,
+				Naming: topology.Naming{Blocks: "rack"},
+			},
+			err: `invalid block naming: "rack" is neither a naming strategy nor a template`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -415,3 +526,97 @@ SwitchName=S3 Nodes=Node[304-306]
 		})
 	}
 }
+
+func TestGenerateOutputExplain(t *testing.T) {
+	ctx := context.Background()
+
+	graph := &topology.Graph{Domains: topology.NewDomainMap()}
+	graph.Domains.AddHost("nvl1", "i1", "n1")
+	graph.Domains.AddHost("nvl1", "i2", "n2")
+	graph.Domains.AddHost("nvl2", "i3", "n3")
+
+	params := map[string]any{
+		"plugin":             topology.TopologyBlock,
+		"topologyConfigPath": filepath.Join(t.TempDir(), "topology.conf"),
+		KeyExplain:           "n3",
+	}
+
+	data, httpErr := GenerateOutput(ctx, graph, params)
+	require.Nil(t, httpErr)
+	require.JSONEq(t, `{
+  "node": "n3",
+  "instanceID": "i3",
+  "domain": "nvl2",
+  "domainOrder": 2,
+  "blocks": [
+    {"block": "block002", "position": 2, "levels": [{"blockSize": 2, "block": 1, "domains": ["nvl1", "nvl2"]}]}
+  ],
+  "trail": [
+    "provider reported accelerator domain \"nvl2\"",
+    "domain \"nvl2\" is block 2 of 2, ordered by domain name",
+    "placed in block002, block 2 of 2",
+    "block size 2: in block 1 of 2 base blocks with domains [nvl1 nvl2]"
+  ]
+}`, string(data))
+	require.NoFileExists(t, params["topologyConfigPath"].(string))
+
+	params[KeyExplain] = "n4"
+	_, httpErr = GenerateOutput(ctx, graph, params)
+	require.NotNil(t, httpErr)
+	require.Equal(t, http.StatusNotFound, httpErr.Code())
+}
+
+func TestGenerateOutputIDMap(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	idMapPath := filepath.Join(dir, "id-map.json")
+	topoPath := filepath.Join(dir, "topology.conf")
+	params := map[string]any{
+		"plugin":    topology.TopologyBlock,
+		"idMapPath": idMapPath,
+	}
+	header := fmt.Sprintf(TopologyHeader, topology.TopologyBlock)
+
+	graph := &topology.Graph{Domains: topology.NewDomainMap()}
+	graph.Domains.AddHost("nvl2", "i2", "n2")
+
+	// the ID map is not written when the topology config is only returned
+	data, httpErr := GenerateOutput(ctx, graph, params)
+	require.Nil(t, httpErr)
+	require.Equal(t, "# block001=nvl2\nBlockName=block001 Nodes=n2\nBlockSizes=1\n", string(data))
+	require.NoFileExists(t, idMapPath)
+
+	params["topologyConfigPath"] = topoPath
+	_, httpErr = GenerateOutput(ctx, graph, params)
+	require.Nil(t, httpErr)
+	data, err := os.ReadFile(topoPath)
+	require.NoError(t, err)
+	require.Equal(t, header+"# block001=nvl2\nBlockName=block001 Nodes=n2\nBlockSizes=1\n", string(data))
+
+	graph.Domains.AddHost("nvl1", "i1", "n1")
+	_, httpErr = GenerateOutput(ctx, graph, params)
+	require.Nil(t, httpErr)
+	data, err = os.ReadFile(topoPath)
+	require.NoError(t, err)
+	require.Equal(t, header+"# block002=nvl1\nBlockName=block002 Nodes=n1\n# block001=nvl2\nBlockName=block001 Nodes=n2\nBlockSizes=1,2\n", string(data))
+
+	idMap, err := os.ReadFile(idMapPath)
+	require.NoError(t, err)
+	require.Equal(t, `{"blocks":{"":{"nvl1":"block002","nvl2":"block001"}}}`+"\n", string(idMap))
+
+	// the ID map is not written when the topology config cannot be written
+	graph.Domains.AddHost("nvl3", "i3", "n3")
+	params["topologyConfigPath"] = dir
+	_, httpErr = GenerateOutput(ctx, graph, params)
+	require.NotNil(t, httpErr)
+	require.Equal(t, http.StatusInternalServerError, httpErr.Code())
+	idMap, err = os.ReadFile(idMapPath)
+	require.NoError(t, err)
+	require.Equal(t, `{"blocks":{"":{"nvl1":"block002","nvl2":"block001"}}}`+"\n", string(idMap))
+
+	params["topologyConfigPath"] = topoPath
+	params["idMapPath"] = dir
+	_, httpErr = GenerateOutput(ctx, graph, params)
+	require.NotNil(t, httpErr)
+	require.Equal(t, http.StatusInternalServerError, httpErr.Code())
+}
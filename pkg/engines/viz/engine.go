@@ -0,0 +1,105 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package viz
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/files"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	NAME = "viz"
+
+	FormatDOT     = "dot"
+	FormatGraphML = "graphml"
+	FormatMermaid = "mermaid"
+)
+
+type VizEngine struct {
+	params *Params
+}
+
+type Params struct {
+	// Format is one of "dot" (default), "graphml" or "mermaid"
+	Format string `mapstructure:"format"`
+	// CollapseNodes replaces the compute nodes of each switch with a node count per accelerator domain
+	CollapseNodes      bool   `mapstructure:"collapseNodes"`
+	TopologyConfigPath string `mapstructure:"topologyConfigPath"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(_ context.Context, params engines.Config) (engines.Engine, *httperr.Error) {
+	p, err := getParameters(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return &VizEngine{
+		params: p,
+	}, nil
+}
+
+func getParameters(params engines.Config) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	switch p.Format {
+	case "":
+		p.Format = FormatDOT
+	case FormatDOT, FormatGraphML, FormatMermaid:
+		// nop
+	default:
+		return nil, fmt.Errorf("unsupported format %q", p.Format)
+	}
+
+	return p, nil
+}
+
+func (eng *VizEngine) GenerateOutput(_ context.Context, graph *topology.Graph, _ map[string]any) ([]byte, *httperr.Error) {
+	g := newVizGraph(graph, eng.params.CollapseNodes)
+
+	buf := &bytes.Buffer{}
+	var err error
+	switch eng.params.Format {
+	case FormatGraphML:
+		err = g.writeGraphML(buf)
+	case FormatMermaid:
+		err = g.writeMermaid(buf)
+	default:
+		err = g.writeDOT(buf)
+	}
+	if err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	if len(eng.params.TopologyConfigPath) == 0 {
+		return buf.Bytes(), nil
+	}
+
+	if err := files.Create(eng.params.TopologyConfigPath, buf.Bytes()); err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	return []byte("OK\n"), nil
+}
+
+func (eng *VizEngine) GetComputeInstances(_ context.Context, _ any) ([]topology.ComputeInstances, *httperr.Error) {
+	return nil, httperr.NewError(http.StatusBadRequest,
+		"viz engine requires nodes in the request or a provider that can supply compute instances")
+}
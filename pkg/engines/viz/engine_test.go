@@ -0,0 +1,206 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package viz
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+const testDOT = `digraph topology {
+  rankdir=TB;
+  "S1" [label="S1", shape=box];
+  "S2" [label="S2", shape=box];
+  "S3" [label="S3", shape=box];
+  "I34" [label="Node304", shape=ellipse];
+  "I35" [label="Node305", shape=ellipse];
+  "I36" [label="Node306", shape=ellipse];
+  subgraph cluster_0 {
+    label="nvl-1";
+    style=dashed;
+    "I21" [label="Node201", shape=ellipse];
+    "I22" [label="Node202", shape=ellipse];
+    "I25" [label="Node205", shape=ellipse];
+  }
+  "S1" -> "S2";
+  "S1" -> "S3";
+  "S2" -> "I21";
+  "S2" -> "I22";
+  "S2" -> "I25";
+  "S3" -> "I34";
+  "S3" -> "I35";
+  "S3" -> "I36";
+}
+`
+
+const testMermaidCollapsed = `flowchart TD
+  v0["S1"]
+  v1["S2"]
+  v3["S3"]
+  v4[["3 nodes"]]
+  subgraph d0["nvl-1"]
+    v2[["3 nodes"]]
+  end
+  v0 --> v1
+  v0 --> v3
+  v1 --> v2
+  v3 --> v4
+`
+
+func testGraph() *topology.Graph {
+	graph, _ := translate.GetTreeTestSet(false)
+	graph.Domains = topology.NewDomainMap()
+	graph.Domains.AddHost("nvl-1", "I21", "Node201")
+	graph.Domains.AddHost("nvl-1", "I22", "Node202")
+	graph.Domains.AddHost("nvl-1", "I25", "Node205")
+	return graph
+}
+
+func TestNamedLoader(t *testing.T) {
+	name, _ := NamedLoader()
+	require.Equal(t, NAME, name)
+}
+
+func TestGetParameters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]any
+		want   *Params
+		err    string
+	}{
+		{
+			name:   "Case 1: default format",
+			params: nil,
+			want:   &Params{Format: FormatDOT},
+		},
+		{
+			name:   "Case 2: valid params",
+			params: map[string]any{"format": "mermaid", "collapseNodes": true, "topologyConfigPath": "/tmp/x"},
+			want:   &Params{Format: FormatMermaid, CollapseNodes: true, TopologyConfigPath: "/tmp/x"},
+		},
+		{
+			name:   "Case 3: invalid format",
+			params: map[string]any{"format": "svg"},
+			err:    `unsupported format "svg"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := getParameters(tc.params)
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGenerateOutput(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dot", func(t *testing.T) {
+		out, herr := (&VizEngine{params: &Params{Format: FormatDOT}}).GenerateOutput(ctx, testGraph(), nil)
+		require.Nil(t, herr)
+		require.Equal(t, testDOT, string(out))
+	})
+
+	t.Run("collapsed mermaid", func(t *testing.T) {
+		eng := &VizEngine{params: &Params{Format: FormatMermaid, CollapseNodes: true}}
+		out, herr := eng.GenerateOutput(ctx, testGraph(), nil)
+		require.Nil(t, herr)
+		require.Equal(t, testMermaidCollapsed, string(out))
+	})
+
+	t.Run("graphml is well-formed", func(t *testing.T) {
+		graph := testGraph()
+		graph.Tiers.Vertices["S1"].Name = `spine<"1">`
+		out, herr := (&VizEngine{params: &Params{Format: FormatGraphML}}).GenerateOutput(ctx, graph, nil)
+		require.Nil(t, herr)
+
+		var doc struct {
+			Graph struct {
+				Nodes []struct {
+					ID string `xml:"id,attr"`
+				} `xml:"node"`
+				Edges []struct {
+					Source string `xml:"source,attr"`
+				} `xml:"edge"`
+			} `xml:"graph"`
+		}
+		require.NoError(t, xml.Unmarshal(out, &doc))
+		// 3 switches, 3 nodes outside of domains, 1 domain
+		require.Len(t, doc.Graph.Nodes, 7)
+		require.Equal(t, "domain:nvl-1", doc.Graph.Nodes[6].ID)
+		require.Len(t, doc.Graph.Edges, 8)
+		require.True(t, strings.Contains(string(out), "spine&lt;&#34;1&#34;&gt;"))
+	})
+
+	t.Run("multi-parent links", func(t *testing.T) {
+		n1 := &topology.Vertex{ID: "n1", Name: "node1"}
+		leaf := &topology.Vertex{ID: "L1", Vertices: map[string]*topology.Vertex{"n1": n1}}
+		graph := &topology.Graph{Tiers: &topology.Vertex{Vertices: map[string]*topology.Vertex{
+			"S1": {ID: "S1", Vertices: map[string]*topology.Vertex{"L1": leaf}, Links: map[string]*topology.Link{"L1": {Count: 4}}},
+			"S2": {ID: "S2", Vertices: map[string]*topology.Vertex{"L1": leaf}, Links: map[string]*topology.Link{"L1": {Count: 1}}},
+		}}}
+		out, herr := (&VizEngine{params: &Params{Format: FormatMermaid}}).GenerateOutput(ctx, graph, nil)
+		require.Nil(t, herr)
+		require.Equal(t, `flowchart TD
+  v0["S1"]
+  v1["S2"]
+  v2["L1"]
+  v3(["node1"])
+  v0 -->|x4| v2
+  v1 --> v2
+  v2 --> v3
+`, string(out))
+	})
+
+	t.Run("domains without tree", func(t *testing.T) {
+		graph := testGraph()
+		graph.Tiers = nil
+		out, herr := (&VizEngine{params: &Params{Format: FormatMermaid}}).GenerateOutput(ctx, graph, nil)
+		require.Nil(t, herr)
+		require.Equal(t, `flowchart TD
+  subgraph d0["nvl-1"]
+    v0(["Node201"])
+    v1(["Node202"])
+    v2(["Node205"])
+  end
+`, string(out))
+	})
+
+	t.Run("output path", func(t *testing.T) {
+		path := t.TempDir() + "/topology.dot"
+		eng := &VizEngine{params: &Params{Format: FormatDOT, TopologyConfigPath: path}}
+		out, herr := eng.GenerateOutput(ctx, testGraph(), nil)
+		require.Nil(t, herr)
+		require.Equal(t, "OK\n", string(out))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, testDOT, string(data))
+	})
+}
+
+func TestGetComputeInstances(t *testing.T) {
+	eng := &VizEngine{params: &Params{}}
+	cis, herr := eng.GetComputeInstances(context.Background(), nil)
+	require.Nil(t, cis)
+	require.NotNil(t, herr)
+	require.Equal(t, http.StatusBadRequest, herr.Code())
+}
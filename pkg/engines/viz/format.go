@@ -0,0 +1,188 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package viz
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// writeDOT renders the graph in Graphviz DOT format.
+// Accelerator domains are rendered as clusters.
+func (g *vizGraph) writeDOT(wr io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("digraph topology {\n")
+	sb.WriteString("  rankdir=TB;\n")
+
+	grouped := g.domainVertices()
+	for _, v := range grouped[""] {
+		writeDOTVertex(&sb, v, "  ")
+	}
+	for i, domain := range g.domains {
+		if len(grouped[domain]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&sb, "    label=%s;\n", dotQuote(domain))
+		sb.WriteString("    style=dashed;\n")
+		for _, v := range grouped[domain] {
+			writeDOTVertex(&sb, v, "    ")
+		}
+		sb.WriteString("  }\n")
+	}
+	for _, e := range g.edges {
+		if e.links > 1 {
+			fmt.Fprintf(&sb, "  %s -> %s [label=\"x%d\"];\n", dotQuote(e.from), dotQuote(e.to), e.links)
+		} else {
+			fmt.Fprintf(&sb, "  %s -> %s;\n", dotQuote(e.from), dotQuote(e.to))
+		}
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(wr, sb.String())
+	return err
+}
+
+func writeDOTVertex(sb *strings.Builder, v *vizVertex, indent string) {
+	var shape string
+	switch v.kind {
+	case kindSwitch:
+		shape = "box"
+	case kindNodes:
+		shape = "folder"
+	default:
+		shape = "ellipse"
+	}
+	fmt.Fprintf(sb, "%s%s [label=%s, shape=%s];\n", indent, dotQuote(v.id), dotQuote(v.label), shape)
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// writeGraphML renders the graph in GraphML format.
+// Accelerator domains are rendered as nested graphs.
+func (g *vizGraph) writeGraphML(wr io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	sb.WriteString(`  <key id="label" for="node" attr.name="label" attr.type="string"/>` + "\n")
+	sb.WriteString(`  <key id="kind" for="node" attr.name="kind" attr.type="string"/>` + "\n")
+	sb.WriteString(`  <key id="tier" for="node" attr.name="tier" attr.type="int"/>` + "\n")
+	sb.WriteString(`  <key id="count" for="node" attr.name="count" attr.type="int"/>` + "\n")
+	sb.WriteString(`  <key id="links" for="edge" attr.name="links" attr.type="int"/>` + "\n")
+	sb.WriteString(`  <graph id="topology" edgedefault="directed">` + "\n")
+
+	grouped := g.domainVertices()
+	for _, v := range grouped[""] {
+		writeGraphMLVertex(&sb, v, "    ")
+	}
+	for _, domain := range g.domains {
+		if len(grouped[domain]) == 0 {
+			continue
+		}
+		id := xmlEscape("domain:" + domain)
+		fmt.Fprintf(&sb, "    <node id=\"%s\">\n", id)
+		fmt.Fprintf(&sb, "      <data key=\"label\">%s</data>\n", xmlEscape(domain))
+		sb.WriteString("      <data key=\"kind\">domain</data>\n")
+		fmt.Fprintf(&sb, "      <graph id=\"%s:\" edgedefault=\"directed\">\n", id)
+		for _, v := range grouped[domain] {
+			writeGraphMLVertex(&sb, v, "        ")
+		}
+		sb.WriteString("      </graph>\n")
+		sb.WriteString("    </node>\n")
+	}
+	for _, e := range g.edges {
+		if e.links > 0 {
+			fmt.Fprintf(&sb, "    <edge source=\"%s\" target=\"%s\">\n", xmlEscape(e.from), xmlEscape(e.to))
+			fmt.Fprintf(&sb, "      <data key=\"links\">%d</data>\n", e.links)
+			sb.WriteString("    </edge>\n")
+		} else {
+			fmt.Fprintf(&sb, "    <edge source=\"%s\" target=\"%s\"/>\n", xmlEscape(e.from), xmlEscape(e.to))
+		}
+	}
+	sb.WriteString("  </graph>\n")
+	sb.WriteString("</graphml>\n")
+
+	_, err := io.WriteString(wr, sb.String())
+	return err
+}
+
+func writeGraphMLVertex(sb *strings.Builder, v *vizVertex, indent string) {
+	fmt.Fprintf(sb, "%s<node id=\"%s\">\n", indent, xmlEscape(v.id))
+	fmt.Fprintf(sb, "%s  <data key=\"label\">%s</data>\n", indent, xmlEscape(v.label))
+	fmt.Fprintf(sb, "%s  <data key=\"kind\">%s</data>\n", indent, v.kind)
+	if v.kind == kindSwitch {
+		fmt.Fprintf(sb, "%s  <data key=\"tier\">%d</data>\n", indent, v.tier)
+	}
+	if v.kind == kindNodes {
+		fmt.Fprintf(sb, "%s  <data key=\"count\">%d</data>\n", indent, v.count)
+	}
+	fmt.Fprintf(sb, "%s</node>\n", indent)
+}
+
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// writeMermaid renders the graph as a Mermaid flowchart.
+// Accelerator domains are rendered as subgraphs.
+func (g *vizGraph) writeMermaid(wr io.Writer) error {
+	// Mermaid IDs are restricted to simple identifiers
+	ids := make(map[string]string, len(g.vertices))
+	for i, v := range g.vertices {
+		ids[v.id] = "v" + strconv.Itoa(i)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+
+	grouped := g.domainVertices()
+	for _, v := range grouped[""] {
+		writeMermaidVertex(&sb, v, ids[v.id], "  ")
+	}
+	for i, domain := range g.domains {
+		if len(grouped[domain]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "  subgraph d%d[%s]\n", i, mermaidQuote(domain))
+		for _, v := range grouped[domain] {
+			writeMermaidVertex(&sb, v, ids[v.id], "    ")
+		}
+		sb.WriteString("  end\n")
+	}
+	for _, e := range g.edges {
+		if e.links > 1 {
+			fmt.Fprintf(&sb, "  %s -->|x%d| %s\n", ids[e.from], e.links, ids[e.to])
+		} else {
+			fmt.Fprintf(&sb, "  %s --> %s\n", ids[e.from], ids[e.to])
+		}
+	}
+
+	_, err := io.WriteString(wr, sb.String())
+	return err
+}
+
+func writeMermaidVertex(sb *strings.Builder, v *vizVertex, id, indent string) {
+	label := mermaidQuote(v.label)
+	switch v.kind {
+	case kindSwitch:
+		fmt.Fprintf(sb, "%s%s[%s]\n", indent, id, label)
+	case kindNodes:
+		fmt.Fprintf(sb, "%s%s[[%s]]\n", indent, id, label)
+	default:
+		fmt.Fprintf(sb, "%s%s([%s])\n", indent, id, label)
+	}
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
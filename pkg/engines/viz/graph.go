@@ -0,0 +1,151 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package viz
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+type vertexKind string
+
+const (
+	kindSwitch vertexKind = "switch"
+	kindNode   vertexKind = "node"
+	kindNodes  vertexKind = "nodes" // collapsed compute nodes
+)
+
+type vizVertex struct {
+	id     string
+	label  string
+	kind   vertexKind
+	tier   int    // switch tier, starting from 1 for leaf switches
+	count  int    // number of collapsed compute nodes
+	domain string // accelerator domain of compute nodes
+}
+
+type vizEdge struct {
+	from, to string
+	links    int // number of parallel links, if known
+}
+
+// vizGraph is a format-neutral rendering of the topology graph:
+// switches ordered from the top tier down, followed by compute nodes.
+type vizGraph struct {
+	vertices []*vizVertex
+	edges    []vizEdge
+	domains  []string
+}
+
+func newVizGraph(graph *topology.Graph, collapse bool) *vizGraph {
+	doc := topology.NewGraphDocument(graph, topology.GraphMetadata{})
+	g := &vizGraph{}
+
+	// map compute node names to accelerator domains
+	nodeDomains := make(map[string]string)
+	nodes := make(map[string]topology.NodeRecord)
+	for _, node := range doc.Nodes {
+		nodes[node.ID] = node
+	}
+	for _, domain := range doc.Domains {
+		g.domains = append(g.domains, domain.Name)
+		for _, host := range domain.Hosts {
+			nodeDomains[host.HostName] = domain.Name
+			// nodes known only from accelerator domains
+			if _, ok := nodes[host.InstanceID]; !ok {
+				nodes[host.InstanceID] = topology.NodeRecord{ID: host.InstanceID, Name: host.HostName}
+			}
+		}
+	}
+
+	switches := append([]topology.SwitchRecord(nil), doc.Switches...)
+	sort.SliceStable(switches, func(i, j int) bool {
+		return switches[i].Tier > switches[j].Tier
+	})
+
+	attached := make(map[string]bool)
+	for _, sw := range switches {
+		label := sw.ID
+		if len(sw.Name) != 0 {
+			label = sw.Name
+		}
+		g.vertices = append(g.vertices, &vizVertex{id: sw.ID, label: label, kind: kindSwitch, tier: sw.Tier})
+		links := make(map[string]int, len(sw.Links))
+		for _, link := range sw.Links {
+			links[link.ID] = link.Count
+		}
+		for _, id := range sw.Switches {
+			g.edges = append(g.edges, vizEdge{from: sw.ID, to: id, links: links[id]})
+		}
+		for _, id := range sw.Nodes {
+			attached[id] = true
+		}
+		if collapse {
+			for _, v := range g.collapseNodes(sw.ID, sw.Nodes, nodes, nodeDomains) {
+				g.edges = append(g.edges, vizEdge{from: sw.ID, to: v.id})
+			}
+		} else {
+			for _, id := range sw.Nodes {
+				g.edges = append(g.edges, vizEdge{from: sw.ID, to: id, links: links[id]})
+			}
+		}
+	}
+
+	ids := slices.Sorted(maps.Keys(nodes))
+	if collapse {
+		detached := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if !attached[id] {
+				detached = append(detached, id)
+			}
+		}
+		g.collapseNodes("", detached, nodes, nodeDomains)
+	} else {
+		for _, id := range ids {
+			node := nodes[id]
+			g.vertices = append(g.vertices, &vizVertex{id: id, label: node.Name, kind: kindNode, domain: nodeDomains[node.Name]})
+		}
+	}
+
+	return g
+}
+
+// collapseNodes adds one vertex per accelerator domain, counting the compute nodes
+// connected to the switch, and returns the added vertices.
+func (g *vizGraph) collapseNodes(switchID string, ids []string, nodes map[string]topology.NodeRecord, nodeDomains map[string]string) []*vizVertex {
+	counts := make(map[string]int)
+	for _, id := range ids {
+		counts[nodeDomains[nodes[id].Name]]++
+	}
+
+	vertices := make([]*vizVertex, 0, len(counts))
+	for _, domain := range slices.Sorted(maps.Keys(counts)) {
+		v := &vizVertex{
+			id:     fmt.Sprintf("%s/nodes/%s", switchID, domain),
+			label:  fmt.Sprintf("%d nodes", counts[domain]),
+			kind:   kindNodes,
+			count:  counts[domain],
+			domain: domain,
+		}
+		g.vertices = append(g.vertices, v)
+		vertices = append(vertices, v)
+	}
+	return vertices
+}
+
+// domainVertices returns vertices grouped by accelerator domain.
+// Vertices outside of any domain are keyed by the empty string.
+func (g *vizGraph) domainVertices() map[string][]*vizVertex {
+	grouped := make(map[string][]*vizVertex)
+	for _, v := range g.vertices {
+		grouped[v.domain] = append(grouped[v.domain], v)
+	}
+	return grouped
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package volcano
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+
+	"github.com/NVIDIA/topograph/internal/config"
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/engines/k8s"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	NAME = "volcano"
+
+	DefaultOwner = "topograph"
+)
+
+// VolcanoEngine publishes the topology as Volcano HyperNodes.
+// Compute instances are discovered from the Kubernetes nodes like in the k8s engine.
+type VolcanoEngine struct {
+	*k8s.K8sEngine
+	client dynamic.Interface
+	params *Params
+}
+
+type Params struct {
+	// NodeSelector (optional) specifies nodes participating in the topology
+	NodeSelector map[string]string `mapstructure:"nodeSelector"`
+	// Owner (optional) is the value of the owner label of the HyperNodes managed by the engine
+	Owner string `mapstructure:"owner"`
+}
+
+func NamedLoader() (string, engines.Loader) {
+	return NAME, Loader
+}
+
+func Loader(ctx context.Context, params engines.Config) (engines.Engine, *httperr.Error) {
+	p, err := getParameters(params)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	eng, httpErr := k8s.Loader(ctx, params)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+	}
+
+	return &VolcanoEngine{
+		K8sEngine: eng.(*k8s.K8sEngine),
+		client:    client,
+		params:    p,
+	}, nil
+}
+
+func getParameters(params engines.Config) (*Params, error) {
+	p := &Params{}
+	if err := config.Decode(params, p); err != nil {
+		return nil, err
+	}
+
+	if len(p.Owner) == 0 {
+		p.Owner = DefaultOwner
+	}
+	if errs := validation.IsValidLabelValue(p.Owner); len(errs) != 0 {
+		return nil, fmt.Errorf("invalid owner %q: %s", p.Owner, strings.Join(errs, "; "))
+	}
+
+	return p, nil
+}
+
+func (eng *VolcanoEngine) GenerateOutput(ctx context.Context, graph *topology.Graph, _ map[string]any) ([]byte, *httperr.Error) {
+	// an empty topology would delete all the HyperNodes of the owner
+	hyperNodes := getHyperNodes(graph)
+	if len(hyperNodes) == 0 {
+		return nil, httperr.NewError(http.StatusBadRequest, "no topology data for HyperNodes")
+	}
+
+	if err := reconcile(ctx, eng.client, eng.params.Owner, hyperNodes); err != nil {
+		return nil, httperr.NewError(http.StatusBadGateway, err.Error())
+	}
+
+	return []byte("OK\n"), nil
+}
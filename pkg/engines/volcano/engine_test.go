@@ -0,0 +1,76 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package volcano
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+func TestNamedLoader(t *testing.T) {
+	name, _ := NamedLoader()
+	require.Equal(t, NAME, name)
+}
+
+func TestGetParameters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]any
+		want   *Params
+		err    string
+	}{
+		{
+			name:   "Case 1: default owner",
+			params: nil,
+			want:   &Params{Owner: DefaultOwner},
+		},
+		{
+			name:   "Case 2: valid params",
+			params: map[string]any{"nodeSelector": map[string]string{"pool": "gpu"}, "owner": "gpu-pool"},
+			want:   &Params{NodeSelector: map[string]string{"pool": "gpu"}, Owner: "gpu-pool"},
+		},
+		{
+			name:   "Case 3: invalid owner",
+			params: map[string]any{"owner": "gpu pool"},
+			err:    `invalid owner "gpu pool"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := getParameters(tc.params)
+			if len(tc.err) != 0 {
+				require.ErrorContains(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGenerateOutput(t *testing.T) {
+	ctx := context.TODO()
+	client := newFakeClient()
+	eng := &VolcanoEngine{client: client, params: &Params{Owner: DefaultOwner}}
+
+	graph, _ := translate.GetTreeTestSet(false)
+	out, httpErr := eng.GenerateOutput(ctx, graph, nil)
+	require.Nil(t, httpErr)
+	require.Equal(t, "OK\n", string(out))
+	require.Len(t, listHyperNodes(t, client), 3)
+
+	_, httpErr = eng.GenerateOutput(ctx, &topology.Graph{}, nil)
+	require.NotNil(t, httpErr)
+	require.Equal(t, http.StatusBadRequest, httpErr.Code())
+	require.Len(t, listHyperNodes(t, client), 3)
+}
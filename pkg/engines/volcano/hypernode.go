@@ -0,0 +1,180 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package volcano
+
+import (
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	MemberTypeNode      = "Node"
+	MemberTypeHyperNode = "HyperNode"
+
+	prefixSwitch = "switch-"
+	prefixDomain = "domain-"
+
+	// maxNameLength keeps HyperNode names valid as label values
+	maxNameLength = 63
+)
+
+var reInvalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// HyperNode describes a Volcano HyperNode: a switch or an accelerator domain
+// with its tier and its member nodes or lower-tier HyperNodes
+type HyperNode struct {
+	Name    string
+	Tier    int
+	Members []Member
+}
+
+type Member struct {
+	Type string
+	Name string
+}
+
+// getHyperNodes returns the HyperNodes of the topology graph, sorted by tier and name.
+// Accelerator domains form tier 1, and the switches are placed in the tiers above,
+// starting from the leaf switches. A leaf switch lists the accelerator domains
+// whose nodes are all connected to it, and the remaining nodes directly.
+func getHyperNodes(graph *topology.Graph) []*HyperNode {
+	if graph == nil {
+		return nil
+	}
+
+	hyperNodes := []*HyperNode{}
+
+	// node name : domain HyperNode name
+	nodeDomain := make(map[string]string)
+	for _, domainName := range slices.Sorted(maps.Keys(graph.Domains)) {
+		hn := &HyperNode{Name: hyperNodeName(prefixDomain, domainName), Tier: 1}
+		for _, nodeName := range slices.Sorted(maps.Keys(graph.Domains[domainName])) {
+			hn.Members = append(hn.Members, Member{Type: MemberTypeNode, Name: nodeName})
+			nodeDomain[nodeName] = hn.Name
+		}
+		hyperNodes = append(hyperNodes, hn)
+	}
+
+	offset := 0
+	if len(hyperNodes) != 0 {
+		offset = 1
+	}
+
+	treeRoot := graph.ToTree().Tiers
+	if treeRoot == nil {
+		return hyperNodes
+	}
+
+	switches := make(map[string]*HyperNode)
+	var visit func(*topology.Vertex) int
+	visit = func(v *topology.Vertex) int {
+		if len(v.Vertices) == 0 {
+			return 0
+		}
+		name := hyperNodeName(prefixSwitch, v.ID)
+		if hn, ok := switches[name]; ok {
+			return hn.Tier - offset
+		}
+
+		hn := &HyperNode{Name: name}
+		switches[name] = hn
+		height := 0
+		var nodes []string
+		for _, id := range slices.Sorted(maps.Keys(v.Vertices)) {
+			w := v.Vertices[id]
+			h := visit(w)
+			if h == 0 {
+				nodes = append(nodes, w.Name)
+			} else {
+				hn.Members = append(hn.Members, Member{Type: MemberTypeHyperNode, Name: hyperNodeName(prefixSwitch, w.ID)})
+			}
+			height = max(height, h+1)
+		}
+		hn.Tier = height + offset
+		hn.Members = append(hn.Members, getLeafMembers(name, nodes, nodeDomain, graph.Domains)...)
+		return height
+	}
+
+	var tops []*topology.Vertex
+	if len(treeRoot.ID) != 0 {
+		tops = append(tops, treeRoot)
+	} else {
+		for _, id := range slices.Sorted(maps.Keys(treeRoot.Vertices)) {
+			// nodes without topology are not connected to a switch
+			if id != topology.NoTopology {
+				tops = append(tops, treeRoot.Vertices[id])
+			}
+		}
+	}
+	for _, v := range tops {
+		visit(v)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(switches)) {
+		hyperNodes = append(hyperNodes, switches[name])
+	}
+
+	slices.SortStableFunc(hyperNodes, func(a, b *HyperNode) int {
+		return a.Tier - b.Tier
+	})
+
+	return hyperNodes
+}
+
+// getLeafMembers returns the members of a leaf switch connected to the given nodes:
+// the accelerator domains fully connected to the switch, and the other nodes
+func getLeafMembers(switchName string, nodes []string, nodeDomain map[string]string, domains topology.DomainMap) []Member {
+	// domain HyperNode name : number of its nodes connected to the switch
+	counts := make(map[string]int)
+	sizes := make(map[string]int)
+	for domainName, domain := range domains {
+		sizes[hyperNodeName(prefixDomain, domainName)] = len(domain)
+	}
+	for _, nodeName := range nodes {
+		if domain, ok := nodeDomain[nodeName]; ok {
+			counts[domain]++
+		}
+	}
+
+	members := []Member{}
+	for _, domain := range slices.Sorted(maps.Keys(counts)) {
+		if counts[domain] == sizes[domain] {
+			members = append(members, Member{Type: MemberTypeHyperNode, Name: domain})
+		} else {
+			klog.Warningf("accelerator domain HyperNode %q spans several switches, including %q", domain, switchName)
+		}
+	}
+	for _, nodeName := range nodes {
+		if domain, ok := nodeDomain[nodeName]; !ok || counts[domain] != sizes[domain] {
+			members = append(members, Member{Type: MemberTypeNode, Name: nodeName})
+		}
+	}
+
+	return members
+}
+
+// hyperNodeName returns a valid object name for the switch or accelerator domain.
+// Names that had to be altered or shortened get a hash suffix to stay unique.
+func hyperNodeName(prefix, id string) string {
+	name := prefix + strings.Trim(reInvalidNameChars.ReplaceAllString(strings.ToLower(id), "-"), "-")
+	if name == prefix+id && len(name) <= maxNameLength {
+		return name
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+
+	return strings.TrimRight(name[:min(len(name), maxNameLength-len(suffix))], "-") + suffix
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package volcano
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+	"github.com/NVIDIA/topograph/pkg/translate"
+)
+
+func TestGetHyperNodesWithTree(t *testing.T) {
+	graph, _ := translate.GetTreeTestSet(false)
+	s1, s2, s3 := hyperNodeName(prefixSwitch, "S1"), hyperNodeName(prefixSwitch, "S2"), hyperNodeName(prefixSwitch, "S3")
+
+	require.Equal(t, []*HyperNode{
+		{Name: s2, Tier: 1, Members: []Member{
+			{Type: MemberTypeNode, Name: "Node201"},
+			{Type: MemberTypeNode, Name: "Node202"},
+			{Type: MemberTypeNode, Name: "Node205"},
+		}},
+		{Name: s3, Tier: 1, Members: []Member{
+			{Type: MemberTypeNode, Name: "Node304"},
+			{Type: MemberTypeNode, Name: "Node305"},
+			{Type: MemberTypeNode, Name: "Node306"},
+		}},
+		{Name: s1, Tier: 2, Members: []Member{
+			{Type: MemberTypeHyperNode, Name: s2},
+			{Type: MemberTypeHyperNode, Name: s3},
+		}},
+	}, getHyperNodes(graph))
+}
+
+func TestGetHyperNodesWithDomains(t *testing.T) {
+	//
+	//         sw1
+	//        /   \
+	//     sw2     sw3
+	//     / \     / \
+	//   n1   n2 n3   n4
+	//
+	// domain d1 = {n1, n2}, domain d2 = {n3, n4, n5} with n5 not in the tree
+	//
+	n := func(id string) *topology.Vertex { return &topology.Vertex{ID: id, Name: id} }
+	sw2 := &topology.Vertex{ID: "sw2", Vertices: map[string]*topology.Vertex{"n1": n("n1"), "n2": n("n2")}}
+	sw3 := &topology.Vertex{ID: "sw3", Vertices: map[string]*topology.Vertex{"n3": n("n3"), "n4": n("n4")}}
+	sw1 := &topology.Vertex{ID: "sw1", Vertices: map[string]*topology.Vertex{"sw2": sw2, "sw3": sw3}}
+	domains := topology.NewDomainMap()
+	domains.AddHost("d1", "n1", "n1")
+	domains.AddHost("d1", "n2", "n2")
+	domains.AddHost("d2", "n3", "n3")
+	domains.AddHost("d2", "n4", "n4")
+	domains.AddHost("d2", "n5", "n5")
+	graph := &topology.Graph{
+		Tiers:   &topology.Vertex{Vertices: map[string]*topology.Vertex{"sw1": sw1}},
+		Domains: domains,
+	}
+
+	require.Equal(t, []*HyperNode{
+		{Name: "domain-d1", Tier: 1, Members: []Member{
+			{Type: MemberTypeNode, Name: "n1"},
+			{Type: MemberTypeNode, Name: "n2"},
+		}},
+		{Name: "domain-d2", Tier: 1, Members: []Member{
+			{Type: MemberTypeNode, Name: "n3"},
+			{Type: MemberTypeNode, Name: "n4"},
+			{Type: MemberTypeNode, Name: "n5"},
+		}},
+		{Name: "switch-sw2", Tier: 2, Members: []Member{
+			{Type: MemberTypeHyperNode, Name: "domain-d1"},
+		}},
+		// d2 is not fully connected to sw3: its nodes are listed directly
+		{Name: "switch-sw3", Tier: 2, Members: []Member{
+			{Type: MemberTypeNode, Name: "n3"},
+			{Type: MemberTypeNode, Name: "n4"},
+		}},
+		{Name: "switch-sw1", Tier: 3, Members: []Member{
+			{Type: MemberTypeHyperNode, Name: "switch-sw2"},
+			{Type: MemberTypeHyperNode, Name: "switch-sw3"},
+		}},
+	}, getHyperNodes(graph))
+
+	require.Nil(t, getHyperNodes(nil))
+}
+
+func TestHyperNodeName(t *testing.T) {
+	require.Equal(t, "switch-leaf-1", hyperNodeName(prefixSwitch, "leaf-1"))
+	require.Equal(t, "domain-nvl-1", hyperNodeName(prefixDomain, "nvl-1"))
+
+	// altered names are unique
+	require.NotEqual(t, hyperNodeName(prefixSwitch, "S1"), hyperNodeName(prefixSwitch, "s1"))
+	require.Regexp(t, `^switch-s1-[0-9a-f]{8}$`, hyperNodeName(prefixSwitch, "S1"))
+	require.Regexp(t, `^switch-a-b-[0-9a-f]{8}$`, hyperNodeName(prefixSwitch, "a_b"))
+
+	long := hyperNodeName(prefixSwitch, "very-very-long-id-to-check-label-value-limits-of-63-characters")
+	require.Len(t, long, maxNameLength)
+	require.Regexp(t, `^switch-very-.*-[0-9a-f]{8}$`, long)
+}
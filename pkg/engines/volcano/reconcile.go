@@ -0,0 +1,127 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package volcano
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+var hyperNodeGVR = schema.GroupVersionResource{Group: "topology.volcano.sh", Version: "v1alpha1", Resource: "hypernodes"}
+
+// LabelOwner marks the HyperNodes created by Topograph; its value is the engine owner parameter
+const LabelOwner = topology.KeyConfigMapTopologyManagedBy
+
+func (hn *HyperNode) spec() map[string]any {
+	members := make([]any, 0, len(hn.Members))
+	for _, m := range hn.Members {
+		members = append(members, map[string]any{
+			"type": m.Type,
+			"selector": map[string]any{
+				"exactMatch": map[string]any{"name": m.Name},
+			},
+		})
+	}
+	return map[string]any{
+		"tier":    int64(hn.Tier),
+		"members": members,
+	}
+}
+
+// reconcile creates or updates the HyperNodes, and deletes the HyperNodes
+// previously created by the same owner that are no longer in the topology.
+// HyperNodes not created by the owner are left untouched.
+func reconcile(ctx context.Context, client dynamic.Interface, owner string, hyperNodes []*HyperNode) error {
+	res := client.Resource(hyperNodeGVR)
+
+	list, err := res.List(ctx, metav1.ListOptions{LabelSelector: labels.Set{LabelOwner: owner}.String()})
+	if err != nil {
+		return fmt.Errorf("failed to list HyperNodes: %v", err)
+	}
+	stale := make(map[string]bool)
+	for _, item := range list.Items {
+		stale[item.GetName()] = true
+	}
+
+	for _, hn := range hyperNodes {
+		delete(stale, hn.Name)
+		if err = apply(ctx, client, owner, hn); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range list.Items {
+		name := item.GetName()
+		if !stale[name] {
+			continue
+		}
+		klog.Infof("Deleting stale HyperNode %q", name)
+		if err = res.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete HyperNode %q: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+func apply(ctx context.Context, client dynamic.Interface, owner string, hn *HyperNode) error {
+	res := client.Resource(hyperNodeGVR)
+	spec := hn.spec()
+
+	obj, err := res.Get(ctx, hn.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get HyperNode %q: %v", hn.Name, err)
+		}
+
+		obj = &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": hyperNodeGVR.GroupVersion().String(),
+			"kind":       "HyperNode",
+			"metadata": map[string]any{
+				"name":   hn.Name,
+				"labels": map[string]any{LabelOwner: owner},
+			},
+			"spec": spec,
+		}}
+		klog.V(4).Infof("Creating HyperNode %q", hn.Name)
+		if _, err = res.Create(ctx, obj, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create HyperNode %q: %v", hn.Name, err)
+		}
+		return nil
+	}
+
+	if val := obj.GetLabels()[LabelOwner]; val != owner {
+		return fmt.Errorf("HyperNode %q exists and is not owned by %q", hn.Name, owner)
+	}
+
+	current, _, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return fmt.Errorf("invalid spec in HyperNode %q: %v", hn.Name, err)
+	}
+	if reflect.DeepEqual(current, spec) {
+		return nil
+	}
+
+	if err = unstructured.SetNestedMap(obj.Object, spec, "spec"); err != nil {
+		return fmt.Errorf("failed to set spec in HyperNode %q: %v", hn.Name, err)
+	}
+	klog.V(4).Infof("Updating HyperNode %q", hn.Name)
+	if _, err = res.Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update HyperNode %q: %v", hn.Name, err)
+	}
+	return nil
+}
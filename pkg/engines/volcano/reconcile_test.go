@@ -0,0 +1,97 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package volcano
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func newHyperNodeObject(name, owner string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": hyperNodeGVR.GroupVersion().String(),
+		"kind":       "HyperNode",
+		"metadata":   map[string]any{"name": name},
+		"spec":       map[string]any{"tier": int64(1)},
+	}}
+	if len(owner) != 0 {
+		obj.SetLabels(map[string]string{LabelOwner: owner})
+	}
+	return obj
+}
+
+func newFakeClient(objects ...runtime.Object) *fake.FakeDynamicClient {
+	return fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		hyperNodeGVR: "HyperNodeList",
+	}, objects...)
+}
+
+func listHyperNodes(t *testing.T, client *fake.FakeDynamicClient) map[string]map[string]any {
+	list, err := client.Resource(hyperNodeGVR).List(context.TODO(), metav1.ListOptions{})
+	require.NoError(t, err)
+	res := make(map[string]map[string]any)
+	for _, item := range list.Items {
+		spec, _, err := unstructured.NestedMap(item.Object, "spec")
+		require.NoError(t, err)
+		res[item.GetName()] = spec
+	}
+	return res
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.TODO()
+	client := newFakeClient(
+		newHyperNodeObject("switch-stale", DefaultOwner),
+		newHyperNodeObject("switch-other", "other"),
+		newHyperNodeObject("switch-manual", ""),
+		newHyperNodeObject("switch-leaf", DefaultOwner),
+	)
+
+	hyperNodes := []*HyperNode{
+		{Name: "switch-leaf", Tier: 1, Members: []Member{{Type: MemberTypeNode, Name: "n1"}}},
+		{Name: "switch-spine", Tier: 2, Members: []Member{{Type: MemberTypeHyperNode, Name: "switch-leaf"}}},
+	}
+	require.NoError(t, reconcile(ctx, client, DefaultOwner, hyperNodes))
+
+	require.Equal(t, map[string]map[string]any{
+		"switch-other":  {"tier": int64(1)},
+		"switch-manual": {"tier": int64(1)},
+		"switch-leaf": {
+			"tier": int64(1),
+			"members": []any{
+				map[string]any{"type": "Node", "selector": map[string]any{"exactMatch": map[string]any{"name": "n1"}}},
+			},
+		},
+		"switch-spine": {
+			"tier": int64(2),
+			"members": []any{
+				map[string]any{"type": "HyperNode", "selector": map[string]any{"exactMatch": map[string]any{"name": "switch-leaf"}}},
+			},
+		},
+	}, listHyperNodes(t, client))
+
+	obj, err := client.Resource(hyperNodeGVR).Get(ctx, "switch-spine", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{LabelOwner: DefaultOwner}, obj.GetLabels())
+
+	// unchanged HyperNodes are not updated
+	client.ClearActions()
+	require.NoError(t, reconcile(ctx, client, DefaultOwner, hyperNodes))
+	for _, action := range client.Actions() {
+		require.Contains(t, []string{"list", "get"}, action.GetVerb())
+	}
+
+	// HyperNodes of other owners are not overwritten
+	err = reconcile(ctx, client, DefaultOwner, []*HyperNode{{Name: "switch-manual", Tier: 1}})
+	require.EqualError(t, err, `HyperNode "switch-manual" exists and is not owned by "topograph"`)
+}
@@ -21,20 +21,35 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
 var (
-	reEmptyLine, reHCA, reSwitch, reConn, reSwitchName, reNodeName *regexp.Regexp
+	reEmptyLine, reHCA, reSwitch, reConn, reLinkWidth, reSwitchName, reNodeName *regexp.Regexp
+
+	// data rate per lane in Gb/s
+	laneRates = map[string]float64{
+		"SDR":   2.5,
+		"DDR":   5,
+		"QDR":   10,
+		"FDR10": 10,
+		"FDR":   14,
+		"EDR":   25,
+		"HDR":   50,
+		"NDR":   100,
+		"XDR":   200,
+	}
 )
 
 func init() {
 	reEmptyLine = regexp.MustCompile(`^\s*$`)
 	reHCA = regexp.MustCompile(`^Ca\s+\d+\s+"([^"]+)"\s+# "([^"]+)"`)
 	reSwitch = regexp.MustCompile(`^Switch\s+\d+\s+"([^"]+)"\s+# "([^"]+)"`)
-	reConn = regexp.MustCompile(`^\[\d+\]\s+"([^"]+)"\[\d+\](\([0-9a-f]+\))?\s+# "([^"]+)"`)
+	reConn = regexp.MustCompile(`^\[(\d+)\]\s+"([^"]+)"\[(\d+)\](\([0-9a-f]+\))?\s+# "([^"]+)"(.*)$`)
+	reLinkWidth = regexp.MustCompile(`\s(\d+)x([0-9A-Z]+)\s*$`)
 	reSwitchName = regexp.MustCompile(`^[^;:]+;([^;:]+):[^;:]+$`)
 	reNodeName = regexp.MustCompile(`^(\S+)\s\S+$`)
 }
@@ -42,11 +57,15 @@ func init() {
 type Switch struct {
 	ID    string
 	Name  string
-	Conn  map[string]string // ID:name
-	Nodes map[string]string // ID:node name
+	Conn  map[string]string         // ID:name
+	Nodes map[string]string         // ID:node name
+	Links map[string]*topology.Link // ID:parallel links
 }
 
-func GenerateTopologyConfig(data []byte, instances []topology.ComputeInstances) ([]*topology.Vertex, map[string]string, error) {
+// GenerateTopologyConfig builds the switch hierarchy from ibnetdiscover output.
+// Unless preserveLinks is set, switches with identical connections are merged,
+// and the links to the connected vertices are dropped.
+func GenerateTopologyConfig(data []byte, instances []topology.ComputeInstances, preserveLinks bool) ([]*topology.Vertex, map[string]string, error) {
 	switches, hca, err := ParseIbnetdiscoverFile(data)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to parse ibnetdiscover output: %v", err)
@@ -58,6 +77,9 @@ func GenerateTopologyConfig(data []byte, instances []topology.ComputeInstances)
 	for _, v := range roots {
 		top = append(top, v)
 	}
+	if preserveLinks {
+		return top, hca, nil
+	}
 	merger := topology.NewMerger(top)
 	merger.Merge()
 
@@ -99,14 +121,19 @@ func ParseIbnetdiscoverFile(data []byte) (map[string]*Switch, map[string]string,
 				Name:  extractSwitchName(match[2]),
 				Conn:  make(map[string]string),
 				Nodes: make(map[string]string),
+				Links: make(map[string]*topology.Link),
 			}
 			continue
 		}
 
 		if match := reConn.FindStringSubmatch(line); len(match) != 0 && entry != nil {
-			id := match[1]
-			destName := match[3]
+			id := match[2]
+			destName := match[5]
 			entry.Conn[id] = destName
+			if _, ok := entry.Links[id]; !ok {
+				entry.Links[id] = &topology.Link{}
+			}
+			entry.Links[id].Add(parseLink(match[1], match[3], match[6]))
 		}
 	}
 
@@ -141,8 +168,10 @@ func buildTree(switches map[string]*Switch, hca map[string]string, nodesInCluste
 				ID:       swID,
 				Vertices: make(map[string]*topology.Vertex),
 			}
-			for _, nodeName := range sw.Nodes {
+			for conID, nodeName := range sw.Nodes {
 				v.Vertices[nodeName] = &topology.Vertex{ID: nodeName, Name: nodeName}
+				// a compute node can be connected through several HCAs
+				addLink(v, nodeName, sw.Links[conID])
 			}
 			vertices[swID] = v
 			level[swID] = v
@@ -168,6 +197,9 @@ func buildTree(switches map[string]*Switch, hca map[string]string, nodesInCluste
 			}
 			if len(children) != 0 {
 				v := &topology.Vertex{ID: swID, Vertices: children}
+				for conID := range children {
+					addLink(v, conID, sw.Links[conID])
+				}
 				vertices[swID] = v
 				upper[swID] = v
 				sw.Conn = nil
@@ -184,6 +216,35 @@ func buildTree(switches map[string]*Switch, hca map[string]string, nodesInCluste
 	return level
 }
 
+// parseLink returns a single link from the port numbers and the trailing
+// attributes of a connection line, e.g. "lid 1 4xHDR"
+func parseLink(local, remote, attrs string) *topology.Link {
+	link := &topology.Link{Count: 1}
+	localPort, _ := strconv.Atoi(local)
+	remotePort, _ := strconv.Atoi(remote)
+	link.Ports = []topology.Port{{Local: localPort, Remote: remotePort}}
+	if m := reLinkWidth.FindStringSubmatch(attrs); m != nil {
+		link.Width, _ = strconv.Atoi(m[1])
+		link.Speed = m[2]
+		link.Rate = float64(link.Width) * laneRates[link.Speed]
+	}
+	return link
+}
+
+// addLink adds parallel links from the vertex to the connected vertex
+func addLink(v *topology.Vertex, id string, link *topology.Link) {
+	if link == nil {
+		return
+	}
+	if v.Links == nil {
+		v.Links = make(map[string]*topology.Link)
+	}
+	if _, ok := v.Links[id]; !ok {
+		v.Links[id] = &topology.Link{}
+	}
+	v.Links[id].Add(link)
+}
+
 func extractSwitchName(name string) string {
 	if m := reSwitchName.FindStringSubmatch(name); m != nil {
 		return m[1]
@@ -20,6 +20,7 @@ import (
 	"context"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -63,6 +64,7 @@ Switch  1   "Switch-1"         # "switch1"
 					Name:  "switch1",
 					Conn:  make(map[string]string),
 					Nodes: make(map[string]string),
+					Links: make(map[string]*topology.Link),
 				},
 			},
 			expectedHCA: map[string]string{},
@@ -85,6 +87,10 @@ Switch	41 "S-08c0eb03008cc87c"		# "MF0;IB-ComputeSpine-03:MQM8700/U1" enhanced p
 						"S-08c0eb0300539a5c": "MF0;IB-ComputeLeaf-101:MQM8700/U1",
 						"S-08c0eb0300539a9c": "MF0;IB-ComputeLeaf-102:MQM8700/U1",
 					},
+					Links: map[string]*topology.Link{
+						"S-08c0eb0300539a5c": {Count: 1, Ports: []topology.Port{{Local: 1, Remote: 23}}, Width: 4, Speed: "HDR", Rate: 200},
+						"S-08c0eb0300539a9c": {Count: 1, Ports: []topology.Port{{Local: 2, Remote: 23}}},
+					},
 				},
 			},
 			expectedHCA: map[string]string{},
@@ -113,6 +119,10 @@ Switch	41 "S-b8cef603008032b8"		# "MF0;SJC3-A04-IB-ComputeLeaf-007:MQM8700/U1" e
 						"S-08c0eb0300539a5c": "MF0;IB-ComputeLeaf-101:MQM8700/U1",
 						"S-08c0eb0300539a9c": "MF0;IB-ComputeLeaf-102:MQM8700/U1",
 					},
+					Links: map[string]*topology.Link{
+						"S-08c0eb0300539a5c": {Count: 1, Ports: []topology.Port{{Local: 1, Remote: 23}}, Width: 4, Speed: "HDR", Rate: 200},
+						"S-08c0eb0300539a9c": {Count: 1, Ports: []topology.Port{{Local: 2, Remote: 23}}},
+					},
 				},
 				"S-b8cef603008032b8": {
 					ID:   "S-b8cef603008032b8",
@@ -123,6 +133,11 @@ Switch	41 "S-b8cef603008032b8"		# "MF0;SJC3-A04-IB-ComputeLeaf-007:MQM8700/U1" e
 						"S-08c0eb03008cc87c": "MF0;IB-ComputeSpine-03:MQM8700/U1",
 					},
 					Nodes: make(map[string]string),
+					Links: map[string]*topology.Link{
+						"S-08c0eb03008ccc3c": {Count: 1, Ports: []topology.Port{{Local: 21, Remote: 39}}, Width: 4, Speed: "HDR", Rate: 200},
+						"S-08c0eb03008ccb5c": {Count: 1, Ports: []topology.Port{{Local: 22, Remote: 39}}, Width: 4, Speed: "HDR", Rate: 200},
+						"S-08c0eb03008cc87c": {Count: 1, Ports: []topology.Port{{Local: 23, Remote: 39}}, Width: 4, Speed: "HDR", Rate: 200},
+					},
 				},
 			},
 			expectedHCA: map[string]string{"H-043f720300f4bc9e": "ngcprd10-luna3086"},
@@ -265,7 +280,7 @@ func TestGenerateTopologyConfigValid(t *testing.T) {
 		},
 	}
 
-	forest, _, err := GenerateTopologyConfig(data, instances)
+	forest, _, err := GenerateTopologyConfig(data, instances, false)
 	require.NoError(t, err)
 
 	root := &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
@@ -300,7 +315,7 @@ func TestGenerateTopologyConfigInvalid(t *testing.T) {
 		},
 	}
 
-	forest, _, err := GenerateTopologyConfig(data, instances)
+	forest, _, err := GenerateTopologyConfig(data, instances, false)
 	require.NoError(t, err)
 
 	root := &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
@@ -316,3 +331,52 @@ func TestGenerateTopologyConfigInvalid(t *testing.T) {
 	expected := ""
 	require.Equal(t, expected, string(data))
 }
+
+func TestGenerateTopologyConfigPreserveLinks(t *testing.T) {
+	data, err := os.ReadFile("../../tests/output/ibnetdiscover/example.out")
+	require.NoError(t, err)
+
+	_, hca, err := ParseIbnetdiscoverFile(data)
+	require.NoError(t, err)
+	instances := map[string]string{}
+	for _, nodeName := range hca {
+		instances[nodeName] = nodeName
+	}
+	cis := []topology.ComputeInstances{{Region: "local", Instances: instances}}
+
+	toGraph := func(forest []*topology.Vertex) *topology.Graph {
+		root := &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+		for _, v := range forest {
+			root.Vertices[v.ID] = v
+		}
+		return &topology.Graph{Tiers: root}
+	}
+
+	forest, _, err := GenerateTopologyConfig(data, cis, false)
+	require.NoError(t, err)
+	merged := toGraph(forest)
+
+	forest, _, err = GenerateTopologyConfig(data, cis, true)
+	require.NoError(t, err)
+	preserved := toGraph(forest)
+	require.False(t, topology.IsTree(preserved.Tiers))
+
+	// every spine is connected to the leaf with two parallel links
+	spine := preserved.Tiers.Vertices["S-2c5eab0300c26380"]
+	require.NotNil(t, spine)
+	require.Equal(t, &topology.Link{
+		Count: 2,
+		Ports: []topology.Port{{Local: 3, Remote: 37}, {Local: 4, Remote: 38}},
+		Width: 4,
+		Speed: "NDR",
+		Rate:  400,
+	}, spine.Links["S-2c5eab0300b879c0"])
+
+	// tree-based output has the same shape in both cases
+	require.True(t, topology.IsTree(preserved.ToTree().Tiers))
+	expected, err := slurm.GenerateOutputParams(context.TODO(), merged, &slurm.Params{})
+	require.Nil(t, err)
+	actual, err := slurm.GenerateOutputParams(context.TODO(), preserved, &slurm.Params{})
+	require.Nil(t, err)
+	require.Equal(t, strings.Count(string(expected), "\n"), strings.Count(string(actual), "\n"))
+}
@@ -18,6 +18,8 @@ package metrics
 
 import (
 	"fmt"
+	"maps"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -64,6 +66,19 @@ var (
 		[]string{"provider", "node"},
 	)
 
+	nodeTopologyInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      "node_topology_info",
+			Help:      "Network topology of the node.",
+			Subsystem: "topograph",
+		},
+		[]string{"node", "instance", "leaf", "spine", "core", "accelerator", "block"},
+	)
+
+	// nodeTopologyLabels holds the node topology info labels, keyed by node name
+	nodeTopologyLabels = make(map[string]prometheus.Labels)
+	nodeTopologyMutex  sync.Mutex
+
 	validationErrorsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:      "validation_error_total",
@@ -79,6 +94,7 @@ func init() {
 	prometheus.MustRegister(httpRequestDuration)
 	prometheus.MustRegister(topologyRequestDuration)
 	prometheus.MustRegister(missingTopologyNodes)
+	prometheus.MustRegister(nodeTopologyInfo)
 	prometheus.MustRegister(validationErrorsTotal)
 }
 
@@ -100,3 +116,43 @@ func SetMissingTopology(provider, nodename string) {
 func AddValidationError(errorType string) {
 	validationErrorsTotal.WithLabelValues(errorType).Inc()
 }
+
+// NodeTopology is the network topology of a node
+type NodeTopology struct {
+	Node        string
+	Instance    string
+	Leaf        string
+	Spine       string
+	Core        string
+	Accelerator string
+	Block       string
+}
+
+// SetNodeTopology replaces the node topology info, removing the nodes not listed
+func SetNodeTopology(nodes []*NodeTopology) {
+	nodeTopologyMutex.Lock()
+	defer nodeTopologyMutex.Unlock()
+
+	current := make(map[string]prometheus.Labels, len(nodes))
+	for _, node := range nodes {
+		current[node.Node] = prometheus.Labels{
+			"node":        node.Node,
+			"instance":    node.Instance,
+			"leaf":        node.Leaf,
+			"spine":       node.Spine,
+			"core":        node.Core,
+			"accelerator": node.Accelerator,
+			"block":       node.Block,
+		}
+	}
+
+	for nodeName, labels := range nodeTopologyLabels {
+		if cur, ok := current[nodeName]; !ok || !maps.Equal(cur, labels) {
+			nodeTopologyInfo.Delete(labels)
+		}
+	}
+	for _, labels := range current {
+		nodeTopologyInfo.With(labels).Set(1.0)
+	}
+	nodeTopologyLabels = current
+}
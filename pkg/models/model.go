@@ -119,15 +119,16 @@ type switchMaps struct {
 }
 
 func (m *Model) buildSwitchMaps() (*switchMaps, error) {
-	// switch map child:parent
+	// switch map child:primary parent
 	swmap := make(map[string]*Switch)
 	nodeMap := make(map[string]*Switch)
 
 	for _, parent := range m.Switches {
 		for _, sw := range parent.Switches {
-			if p, ok := swmap[sw]; ok {
-				// a child switch cannot have more than one parent switch
-				return nil, fmt.Errorf("switch %q has two parent switches %q and %q", sw, parent.Name, p.Name)
+			// in multi-parent (CLOS) topologies the network layers follow
+			// the parent switch with the lowest name
+			if p, ok := swmap[sw]; ok && p.Name < parent.Name {
+				continue
 			}
 			swmap[sw] = parent
 		}
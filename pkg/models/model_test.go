@@ -300,3 +300,34 @@ blocks:
 		},
 	}, model.Instances)
 }
+
+func TestMultiParentSwitch(t *testing.T) {
+	cfg := `
+switches:
+  spine2:
+    switches: [leaf1, leaf2]
+  spine1:
+    switches: [leaf1, leaf2]
+  leaf1: {}
+  leaf2: {}
+blocks:
+- switch: leaf1
+  nodes: [n1]
+- switch: leaf2
+  nodes: [n2]
+`
+
+	model, err := NewModelFromData([]byte(cfg), "inline")
+	require.NoError(t, err)
+
+	// network layers follow the parent switch with the lowest name
+	require.Equal(t, []string{"leaf1", "spine1"}, model.Nodes["n1"].NetLayers)
+	require.Equal(t, []string{"leaf2", "spine1"}, model.Nodes["n2"].NetLayers)
+
+	// the graph keeps all the links
+	graph, _ := model.ToGraph(nil)
+	require.Len(t, graph.Tiers.Vertices, 2)
+	require.Len(t, graph.Tiers.Vertices["spine1"].Vertices, 2)
+	require.Len(t, graph.Tiers.Vertices["spine2"].Vertices, 2)
+	require.False(t, topology.IsTree(graph.Tiers))
+}
@@ -51,7 +51,7 @@ func (p *Provider) GenerateTopologyConfig(ctx context.Context, _ *int, instances
 		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
 	}
 
-	roots, _, err := ib.GenerateTopologyConfig(output.Bytes(), instances)
+	roots, _, err := ib.GenerateTopologyConfig(output.Bytes(), instances, false)
 	if err != nil {
 		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
 	}
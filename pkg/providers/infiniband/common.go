@@ -25,7 +25,7 @@ type IBNetDiscover interface {
 	Run(context.Context, string) (*bytes.Buffer, error)
 }
 
-func getIbTree(ctx context.Context, cis []topology.ComputeInstances, ibnetdiscover IBNetDiscover) (*topology.Vertex, error) {
+func getIbTree(ctx context.Context, cis []topology.ComputeInstances, ibnetdiscover IBNetDiscover, preserveLinks bool) (*topology.Vertex, error) {
 	nodeVisited := make(map[string]bool)
 	rootMap := make(map[string]*topology.Vertex)
 
@@ -37,7 +37,7 @@ func getIbTree(ctx context.Context, cis []topology.ComputeInstances, ibnetdiscov
 				continue
 			}
 			if strings.Contains(stdout.String(), "Topology file:") {
-				ibRoots, hca, err := ib.GenerateTopologyConfig(stdout.Bytes(), cis)
+				ibRoots, hca, err := ib.GenerateTopologyConfig(stdout.Bytes(), cis, preserveLinks)
 				if err != nil {
 					return nil, fmt.Errorf("IB GenerateTopologyConfig failed: %v", err)
 				}
@@ -66,6 +66,9 @@ func getIbTree(ctx context.Context, cis []topology.ComputeInstances, ibnetdiscov
 	for _, v := range merger.TopTier() {
 		treeRoot.Vertices[v.ID] = v
 	}
+	if preserveLinks {
+		topology.WarnHalfCabledSwitches(treeRoot)
+	}
 
 	return treeRoot, nil
 }
@@ -97,7 +97,7 @@ func TestGetIbTree(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			root, err := getIbTree(ctx, cis, &testIBNetDiscover{err: tc.err})
+			root, err := getIbTree(ctx, cis, &testIBNetDiscover{err: tc.err}, false)
 			require.NoError(t, err)
 			require.NotNil(t, root)
 			require.Equal(t, tc.root, root)
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/NVIDIA/topograph/internal/config"
 	"github.com/NVIDIA/topograph/internal/exec"
 	"github.com/NVIDIA/topograph/internal/httperr"
 	"github.com/NVIDIA/topograph/pkg/providers"
@@ -18,14 +19,27 @@ import (
 
 const NAME_BM = "infiniband-bm"
 
-type ProviderBM struct{}
+type ProviderBM struct {
+	params *ParamsBM
+}
+
+type ParamsBM struct {
+	// PreserveLinks keeps all the links of multi-parent (CLOS) switches
+	// instead of merging the switches into a tree.
+	PreserveLinks bool `mapstructure:"preserveLinks"`
+}
 
 func NamedLoaderBM() (string, providers.Loader) {
 	return NAME_BM, LoaderBM
 }
 
-func LoaderBM(_ context.Context, _ providers.Config) (providers.Provider, *httperr.Error) {
-	return &ProviderBM{}, nil
+func LoaderBM(_ context.Context, cfg providers.Config) (providers.Provider, *httperr.Error) {
+	p := &ParamsBM{}
+	if err := config.Decode(cfg.Params, p); err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	return &ProviderBM{params: p}, nil
 }
 
 func (p *ProviderBM) GenerateTopologyConfig(ctx context.Context, _ *int, cis []topology.ComputeInstances) (*topology.Graph, *httperr.Error) {
@@ -45,7 +59,7 @@ func (p *ProviderBM) GenerateTopologyConfig(ctx context.Context, _ *int, cis []t
 		return nil, httperr.NewError(http.StatusInternalServerError, fmt.Sprintf("failed to populate NVL domains: %v", err))
 	}
 
-	treeRoot, err := getIbTree(ctx, cis, &IBNetDiscoverBM{})
+	treeRoot, err := getIbTree(ctx, cis, &IBNetDiscoverBM{}, p.params.PreserveLinks)
 	if err != nil {
 		return nil, httperr.NewError(http.StatusInternalServerError, fmt.Sprintf("getIbTree failed: %v", err))
 	}
@@ -40,6 +40,10 @@ type Params struct {
 	// as the accelerator domain ID instead of Topograph's node annotation.
 	UseGPUCliqueLabel bool `mapstructure:"useGpuCliqueLabel"`
 
+	// PreserveLinks keeps all the links of multi-parent (CLOS) switches
+	// instead of merging the switches into a tree.
+	PreserveLinks bool `mapstructure:"preserveLinks"`
+
 	// derived fields
 	nodeListOpt *metav1.ListOptions
 }
@@ -104,7 +108,7 @@ func (p *ProviderK8S) GenerateTopologyConfig(ctx context.Context, _ *int, cis []
 	}
 
 	ibnetdiscover := NewIBNetDiscoverK8S(p.config, p.client)
-	treeRoot, err := getIbTree(ctx, cis, ibnetdiscover)
+	treeRoot, err := getIbTree(ctx, cis, ibnetdiscover, p.params.PreserveLinks)
 	if err != nil {
 		return nil, httperr.NewError(http.StatusInternalServerError, fmt.Sprintf("getIbTree failed: %v", err))
 	}
@@ -56,6 +56,13 @@ func TestGetParameters(t *testing.T) {
 				UseGPUCliqueLabel: true,
 			},
 		},
+		{
+			name:   "Case 6: preserve links",
+			params: map[string]any{"preserveLinks": true},
+			ret: &Params{
+				PreserveLinks: true,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
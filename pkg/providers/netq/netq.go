@@ -92,6 +92,9 @@ func (p *Provider) getNetworkTree(ctx context.Context, cis []topology.ComputeIns
 	if len(treeRoot.Vertices) == 0 {
 		return nil, httperr.NewError(http.StatusBadGateway, "no topology available from the provided premises")
 	}
+	if p.params.PreserveLinks {
+		topology.WarnHalfCabledSwitches(treeRoot)
+	}
 
 	return treeRoot, nil
 }
@@ -130,11 +133,12 @@ func (p *Provider) getPremisesTopology(ctx context.Context, cis []topology.Compu
 		return httpErr
 	}
 
-	return parseNetq(treeRoot, data, topology.GetNodeNameMap(cis))
+	return parseNetq(treeRoot, data, topology.GetNodeNameMap(cis), p.params.PreserveLinks)
 }
 
-// parseNetq parses Netq topology output
-func parseNetq(treeRoot *topology.Vertex, data []byte, inputNodes map[string]bool) *httperr.Error {
+// parseNetq parses Netq topology output.
+// Unless preserveLinks is set, multi-parent switches are merged into a tree.
+func parseNetq(treeRoot *topology.Vertex, data []byte, inputNodes map[string]bool, preserveLinks bool) *httperr.Error {
 	var resp []NetqResponse
 	if err := json.Unmarshal(data, &resp); err != nil {
 		return httperr.NewError(http.StatusBadGateway, fmt.Sprintf("netq output read failed: %v", err))
@@ -169,8 +173,8 @@ func parseNetq(treeRoot *topology.Vertex, data []byte, inputNodes map[string]boo
 		}
 	}
 
-	// create map of link IDs [lower node : upper nodes]
-	linksUp := make(map[string]map[string]bool)
+	// create map of link IDs [lower node : upper nodes : number of links]
+	linksUp := make(map[string]map[string]int)
 	for _, link := range resp[0].Links {
 		nodeIDs := strings.Split(link.Id, "-*-")
 		if len(nodeIDs) != 2 {
@@ -190,17 +194,17 @@ func parseNetq(treeRoot *topology.Vertex, data []byte, inputNodes map[string]boo
 
 		up, ok := linksUp[nodeLow]
 		if !ok {
-			up = make(map[string]bool)
+			up = make(map[string]int)
 			linksUp[nodeLow] = up
 		}
-		up[nodeHigh] = true
+		up[nodeHigh]++
 	}
 
 	for {
 		count := len(nodeMap)
 		nextLayer := make(map[string]*topology.Vertex)
 		for id, w := range layer {
-			for up := range linksUp[id] {
+			for up, links := range linksUp[id] {
 				v, ok := nextLayer[up]
 				if !ok {
 					v = nodeMap[up]
@@ -211,6 +215,12 @@ func parseNetq(treeRoot *topology.Vertex, data []byte, inputNodes map[string]boo
 
 				if v != nil {
 					v.Vertices[id] = w
+					if preserveLinks {
+						if v.Links == nil {
+							v.Links = make(map[string]*topology.Link)
+						}
+						v.Links[id] = &topology.Link{Count: links}
+					}
 				} else {
 					klog.Warningf("node ID %q not found", up)
 				}
@@ -229,9 +239,11 @@ func parseNetq(treeRoot *topology.Vertex, data []byte, inputNodes map[string]boo
 	}
 
 	// Ethernet Spectrum-X may have CLOS network and may require merging of switches to a tree format
-	merger := topology.NewMerger(top)
-	merger.Merge()
-	top = merger.TopTier()
+	if !preserveLinks {
+		merger := topology.NewMerger(top)
+		merger.Merge()
+		top = merger.TopTier()
+	}
 
 	for _, node := range top {
 		treeRoot.Vertices[node.ID] = node
@@ -28,7 +28,7 @@ func TestParseNetq(t *testing.T) {
 		"dgx-06": true,
 		"dgx-07": true,
 		"dgx-08": true,
-	})
+	}, false)
 	require.Nil(t, err)
 
 	dgx01 := &topology.Vertex{ID: "node21", Name: "dgx-01"}
@@ -55,11 +55,45 @@ func TestParseNetq(t *testing.T) {
 	// empty input
 	treeRoot = &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
 	data = []byte{}
-	err = parseNetq(treeRoot, data, map[string]bool{})
+	err = parseNetq(treeRoot, data, map[string]bool{}, false)
 	require.EqualError(t, err, "netq output read failed: unexpected end of JSON input")
 
 	// invalid input
 	str := `[{},{}]`
-	err = parseNetq(treeRoot, []byte(str), map[string]bool{})
+	err = parseNetq(treeRoot, []byte(str), map[string]bool{}, false)
 	require.EqualError(t, err, "invalid NetQ response: multiple entries")
 }
+
+func TestParseNetqPreserveLinks(t *testing.T) {
+	data := []byte(`[{
+  "nodes": [{"compounded_nodes": [
+    {"id": "s1", "name": "spine1", "tier": 2},
+    {"id": "s2", "name": "spine2", "tier": 2},
+    {"id": "l1", "name": "leaf1", "tier": 1},
+    {"id": "l2", "name": "leaf2", "tier": 1},
+    {"id": "n1", "name": "node1", "tier": -1},
+    {"id": "n2", "name": "node2", "tier": -1}
+  ]}],
+  "links": [
+    {"id": "n1-*-l1"}, {"id": "n2-*-l2"},
+    {"id": "l1-*-s1"}, {"id": "l1-*-s1"}, {"id": "l2-*-s1"},
+    {"id": "s2-*-l1"}, {"id": "l2-*-s2"}
+  ]
+}]`)
+	inputNodes := map[string]bool{"node1": true, "node2": true}
+
+	// switches with identical connections are merged
+	treeRoot := &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+	require.Nil(t, parseNetq(treeRoot, data, inputNodes, false))
+	require.Len(t, treeRoot.Vertices, 1)
+	require.True(t, topology.IsTree(treeRoot))
+
+	// all the links are preserved
+	treeRoot = &topology.Vertex{Vertices: make(map[string]*topology.Vertex)}
+	require.Nil(t, parseNetq(treeRoot, data, inputNodes, true))
+	require.Len(t, treeRoot.Vertices, 2)
+	require.False(t, topology.IsTree(treeRoot))
+	require.Equal(t, map[string]*topology.Link{"l1": {Count: 2}, "l2": {Count: 1}}, treeRoot.Vertices["s1"].Links)
+	require.Equal(t, map[string]*topology.Link{"l1": {Count: 1}, "l2": {Count: 1}}, treeRoot.Vertices["s2"].Links)
+	require.Equal(t, map[string]*topology.Link{"n1": {Count: 1}}, treeRoot.Vertices["s1"].Vertices["l1"].Links)
+}
@@ -28,6 +28,10 @@ type Provider struct {
 
 type ProviderParams struct {
 	ApiURL string `mapstructure:"apiUrl"`
+
+	// PreserveLinks keeps all the links of multi-parent (CLOS) switches
+	// instead of merging the switches into a tree.
+	PreserveLinks bool `mapstructure:"preserveLinks"`
 }
 
 type Credentials struct {
@@ -18,10 +18,18 @@ package registry
 
 import (
 	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/engines/flux"
 	"github.com/NVIDIA/topograph/pkg/engines/graph"
+	"github.com/NVIDIA/topograph/pkg/engines/hostfile"
 	"github.com/NVIDIA/topograph/pkg/engines/k8s"
+	"github.com/NVIDIA/topograph/pkg/engines/kueue"
+	"github.com/NVIDIA/topograph/pkg/engines/pbs"
+	"github.com/NVIDIA/topograph/pkg/engines/prometheus"
+	"github.com/NVIDIA/topograph/pkg/engines/report"
 	"github.com/NVIDIA/topograph/pkg/engines/slinky"
 	"github.com/NVIDIA/topograph/pkg/engines/slurm"
+	"github.com/NVIDIA/topograph/pkg/engines/viz"
+	"github.com/NVIDIA/topograph/pkg/engines/volcano"
 	"github.com/NVIDIA/topograph/pkg/providers"
 	"github.com/NVIDIA/topograph/pkg/providers/aws"
 	"github.com/NVIDIA/topograph/pkg/providers/cw"
@@ -65,4 +73,12 @@ var Engines = engines.NewRegistry(
 	graph.NamedLoader,
 	slurm.NamedLoader,
 	slinky.NamedLoader,
+	viz.NamedLoader,
+	report.NamedLoader,
+	kueue.NamedLoader,
+	volcano.NamedLoader,
+	flux.NamedLoader,
+	pbs.NamedLoader,
+	hostfile.NamedLoader,
+	prometheus.NamedLoader,
 )
@@ -18,7 +18,10 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"k8s.io/klog/v2"
@@ -26,6 +29,8 @@ import (
 	"github.com/NVIDIA/topograph/internal/httperr"
 	"github.com/NVIDIA/topograph/internal/httpreq"
 	"github.com/NVIDIA/topograph/pkg/engines"
+	"github.com/NVIDIA/topograph/pkg/engines/k8s"
+	"github.com/NVIDIA/topograph/pkg/engines/kueue"
 	"github.com/NVIDIA/topograph/pkg/metrics"
 	"github.com/NVIDIA/topograph/pkg/providers"
 	"github.com/NVIDIA/topograph/pkg/registry"
@@ -64,7 +69,7 @@ func processRequestWithRetries(tr *topology.Request, f func(*topology.Request) (
 		} else {
 			code = http.StatusOK
 		}
-		metrics.AddTopologyRequest(tr.Provider.Name, tr.Engine.Name, code, time.Since(start))
+		metrics.AddTopologyRequest(tr.Provider.Name, tr.EngineName(), code, time.Since(start))
 
 		if !httpreq.ShouldRetry(code) || attempt == maxRetries {
 			return ret, err
@@ -76,13 +81,31 @@ func processRequestWithRetries(tr *topology.Request, f func(*topology.Request) (
 	}
 }
 
+// getEngineLoader returns the loader of the engine,
+// with the label keys of the command line as the defaults of the k8s and kueue engines
+func getEngineLoader(name string) (engines.Loader, *httperr.Error) {
+	if keys := srv.cfg.LabelKeys; keys != nil {
+		switch name {
+		case k8s.NAME:
+			return k8s.NewLoader(*keys), nil
+		case kueue.NAME:
+			return kueue.NewLoader(*keys), nil
+		}
+	}
+	return registry.Engines.Get(name)
+}
+
 func processTopologyRequest(tr *topology.Request) ([]byte, *httperr.Error) {
-	klog.InfoS("Creating topology config", "provider", tr.Provider.Name, "engine", tr.Engine.Name)
+	klog.InfoS("Creating topology config", "provider", tr.Provider.Name, "engine", tr.EngineName())
 	defer klog.Info("Topology request completed")
 
-	engLoader, err := registry.Engines.Get(tr.Engine.Name)
-	if err != nil {
-		return nil, err
+	engLoaders := make([]engines.Loader, 0, len(tr.GetEngines()))
+	for _, e := range tr.GetEngines() {
+		engLoader, err := getEngineLoader(e.Name)
+		if err != nil {
+			return nil, err
+		}
+		engLoaders = append(engLoaders, engLoader)
 	}
 
 	prvLoader, err := registry.Providers.Get(tr.Provider.Name)
@@ -92,9 +115,13 @@ func processTopologyRequest(tr *topology.Request) ([]byte, *httperr.Error) {
 
 	ctx := context.Background()
 
-	eng, err := engLoader(ctx, tr.Engine.Params)
-	if err != nil {
-		return nil, err
+	engs := make([]engines.Engine, 0, len(engLoaders))
+	for i, e := range tr.GetEngines() {
+		eng, err := engLoaders[i](ctx, e.Params)
+		if err != nil {
+			return nil, err
+		}
+		engs = append(engs, eng)
 	}
 
 	prv, err := prvLoader(ctx, providers.Config{
@@ -105,8 +132,9 @@ func processTopologyRequest(tr *topology.Request) ([]byte, *httperr.Error) {
 		return nil, err
 	}
 
-	// if the instance/node mapping is not provided in the payload, get the mapping from the provider
-	computeInstances, err := getComputeInstances(ctx, eng, prv, tr.Nodes)
+	// if the instance/node mapping is not provided in the payload, get the mapping from the provider,
+	// or from the first engine that can supply it
+	computeInstances, err := getComputeInstances(ctx, engs, prv, tr.Nodes)
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +144,45 @@ func processTopologyRequest(tr *topology.Request) ([]byte, *httperr.Error) {
 		return nil, err
 	}
 
-	return eng.GenerateOutput(ctx, graph, tr.Engine.Params)
+	if len(tr.Engines) == 0 {
+		return engs[0].GenerateOutput(ctx, graph, tr.Engine.Params)
+	}
+
+	return generateOutputs(ctx, graph, tr.Engines, engs)
+}
+
+// generateOutputs invokes every engine of a fan-out request on the same topology graph,
+// and returns the combined engine results. If any engine fails, the combined results
+// are returned with the Multi-Status code, so that the request is not retried
+// and the engines that succeeded are not invoked again.
+func generateOutputs(ctx context.Context, graph *topology.Graph, params []topology.Engine, engs []engines.Engine) ([]byte, *httperr.Error) {
+	var failed []string
+	results := make([]*topology.EngineResult, 0, len(engs))
+	for i, eng := range engs {
+		res := &topology.EngineResult{Engine: params[i].Name, Status: http.StatusOK}
+		out, err := eng.GenerateOutput(ctx, graph, params[i].Params)
+		if err != nil {
+			klog.Errorf("Engine %s failed: %v", params[i].Name, err)
+			res.Status = err.Code()
+			res.Error = err.Error()
+			failed = append(failed, params[i].Name)
+		} else {
+			res.Output = string(out)
+		}
+		results = append(results, res)
+	}
+
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, err.Error())
+	}
+	data = append(data, '\n')
+
+	if len(failed) != 0 {
+		return data, httperr.NewError(http.StatusMultiStatus, fmt.Sprintf("failed engines: %s", strings.Join(failed, ", ")))
+	}
+
+	return data, nil
 }
 
 func checkCredentials(payloadCreds, cfgCreds map[string]any) map[string]any {
@@ -126,7 +192,9 @@ func checkCredentials(payloadCreds, cfgCreds map[string]any) map[string]any {
 	return cfgCreds
 }
 
-func getComputeInstances(ctx context.Context, eng engines.Engine, prv providers.Provider, requested []topology.ComputeInstances) ([]topology.ComputeInstances, *httperr.Error) {
+// getComputeInstances returns the requested compute instances, or the ones supplied by the provider,
+// or by the first engine that can supply them. An engine that cannot supply them returns Bad Request.
+func getComputeInstances(ctx context.Context, engs []engines.Engine, prv providers.Provider, requested []topology.ComputeInstances) ([]topology.ComputeInstances, *httperr.Error) {
 	if len(requested) != 0 {
 		return requested, nil
 	}
@@ -135,5 +203,16 @@ func getComputeInstances(ctx context.Context, eng engines.Engine, prv providers.
 		return p.GetComputeInstances(ctx)
 	}
 
-	return eng.GetComputeInstances(ctx, prv)
+	var firstErr *httperr.Error
+	for _, eng := range engs {
+		computeInstances, err := eng.GetComputeInstances(ctx, prv)
+		if err == nil || err.Code() != http.StatusBadRequest {
+			return computeInstances, err
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return nil, firstErr
 }
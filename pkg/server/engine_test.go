@@ -17,6 +17,7 @@
 package server
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 	"time"
@@ -221,3 +222,69 @@ SwitchName=S3 Nodes=I[34-36]
 		})
 	}
 }
+
+func TestProcessFanOutRequest(t *testing.T) {
+	srv = &HttpServer{
+		cfg: &config.Config{},
+	}
+	provider := topology.Provider{
+		Name:   "test",
+		Params: map[string]any{"modelFileName": "small-tree.yaml"},
+	}
+
+	tr := &topology.Request{
+		Provider: provider,
+		Engines: []topology.Engine{
+			{Name: "slurm"},
+			{Name: "hostfile", Params: map[string]any{"format": "list"}},
+		},
+	}
+	data, err := processTopologyRequest(tr)
+	require.Nil(t, err)
+
+	var results []*topology.EngineResult
+	require.NoError(t, json.Unmarshal(data, &results))
+	require.Equal(t, []*topology.EngineResult{
+		{
+			Engine: "slurm",
+			Status: http.StatusOK,
+			Output: "SwitchName=S1 Switches=S[2-3]\nSwitchName=S2 Nodes=I[21-22,25]\nSwitchName=S3 Nodes=I[34-36]\n",
+		},
+		{
+			Engine: "hostfile",
+			Status: http.StatusOK,
+			Output: "I21\nI22\nI25\nI34\nI35\nI36\n",
+		},
+	}, results)
+
+	// an engine fails to load
+	tr.Engines[1].Params = map[string]any{"format": "csv"}
+	_, err = processTopologyRequest(tr)
+	require.NotNil(t, err)
+	require.EqualError(t, err, `unsupported format "csv"`)
+	require.Equal(t, http.StatusBadRequest, err.Code())
+
+	// an engine fails to generate the output
+	tr.Engines = []topology.Engine{
+		{Name: "hostfile"},
+		{Name: "pbs", Params: map[string]any{"execute": true}}, // no qmgr
+	}
+	data, err = processTopologyRequest(tr)
+	require.NotNil(t, err)
+	require.Equal(t, http.StatusMultiStatus, err.Code())
+	require.EqualError(t, err, "failed engines: pbs")
+	require.NoError(t, json.Unmarshal(data, &results))
+	require.Len(t, results, 2)
+	require.Equal(t, http.StatusOK, results[0].Status)
+	require.Equal(t, http.StatusInternalServerError, results[1].Status)
+	require.NotEmpty(t, results[1].Error)
+
+	// a failed fan-out is not retried
+	calls := 0
+	_, err = processRequestWithRetries(tr, func(tr *topology.Request) ([]byte, *httperr.Error) {
+		calls++
+		return processTopologyRequest(tr)
+	})
+	require.Equal(t, http.StatusMultiStatus, err.Code())
+	require.Equal(t, 1, calls)
+}
@@ -28,6 +28,9 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/NVIDIA/topograph/pkg/config"
+	reportengine "github.com/NVIDIA/topograph/pkg/engines/report"
+	"github.com/NVIDIA/topograph/pkg/engines/slinky"
+	"github.com/NVIDIA/topograph/pkg/engines/slurm"
 	"github.com/NVIDIA/topograph/pkg/metrics"
 	"github.com/NVIDIA/topograph/pkg/providers/test"
 	"github.com/NVIDIA/topograph/pkg/registry"
@@ -96,6 +99,8 @@ func initHttpServer(ctx context.Context, cfg *config.Config) *HttpServer {
 	mux.HandleFunc("/v1/generate", generate)
 	mux.HandleFunc("/v1/topology", getresult)
 	mux.HandleFunc("/v1/lookup", lookup)
+	mux.HandleFunc("/v1/report", report)
+	mux.HandleFunc("/v1/explain", explain)
 	mux.HandleFunc("/healthz", healthz)
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -144,6 +149,47 @@ func generate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	submit(w, tr)
+}
+
+// report submits the topology request to the report engine,
+// regardless of the engine given in the payload or in the config
+func report(w http.ResponseWriter, r *http.Request) {
+	tr := readEngineRequest(w, r, reportengine.NAME)
+	if tr == nil {
+		return
+	}
+
+	submit(w, tr)
+}
+
+// explain submits the topology request with the node given in the query,
+// to return the decision trail of its placement instead of the topology config
+func explain(w http.ResponseWriter, r *http.Request) {
+	node := r.URL.Query().Get("node")
+	if len(node) == 0 {
+		http.Error(w, "must specify node", http.StatusBadRequest)
+		return
+	}
+
+	tr := readRequest(w, r)
+	if tr == nil {
+		return
+	}
+
+	if len(tr.Engines) != 0 || (tr.Engine.Name != slurm.NAME && tr.Engine.Name != slinky.NAME) {
+		http.Error(w, fmt.Sprintf("explain requires the %s or %s engine", slurm.NAME, slinky.NAME), http.StatusBadRequest)
+		return
+	}
+	if tr.Engine.Params == nil {
+		tr.Engine.Params = make(map[string]any)
+	}
+	tr.Engine.Params[slurm.KeyExplain] = node
+
+	submit(w, tr)
+}
+
+func submit(w http.ResponseWriter, tr *topology.Request) {
 	// Check for test provider short-circuit [test cases handling]
 	if test.HandleTestProviderRequest(w, tr) {
 		return
@@ -160,6 +206,11 @@ func generate(w http.ResponseWriter, r *http.Request) {
 }
 
 func readRequest(w http.ResponseWriter, r *http.Request) *topology.Request {
+	return readEngineRequest(w, r, "")
+}
+
+// readEngineRequest reads the topology request. A non-empty engine overrides the requested one.
+func readEngineRequest(w http.ResponseWriter, r *http.Request, engine string) *topology.Request {
 	start := time.Now()
 
 	if r.Method != http.MethodPost {
@@ -181,7 +232,14 @@ func readRequest(w http.ResponseWriter, r *http.Request) *topology.Request {
 	if len(tr.Provider.Name) == 0 {
 		tr.Provider.Name = srv.cfg.Provider
 	}
-	if len(tr.Engine.Name) == 0 {
+	if len(engine) != 0 {
+		tr.Engine.Name = engine
+		tr.Engines = nil
+	} else if len(tr.Engines) != 0 {
+		if len(tr.Engine.Name) != 0 {
+			return httpError(w, tr.Provider.Name, tr.EngineName(), "engine and engines are mutually exclusive", http.StatusBadRequest, time.Since(start))
+		}
+	} else if len(tr.Engine.Name) == 0 {
 		tr.Engine.Name = srv.cfg.Engine
 	}
 
@@ -195,24 +253,40 @@ func readRequest(w http.ResponseWriter, r *http.Request) *topology.Request {
 		}
 	}
 
-	for k, v := range srv.cfg.EngineParams {
-		if tr.Engine.Params == nil {
-			tr.Engine.Params = make(map[string]any)
+	// For a fan-out request, or an engine overridden by the endpoint, e.g., /v1/report,
+	// the engine params from the config apply to the engines named as the configured engine
+	if len(tr.Engines) == 0 {
+		if len(engine) == 0 || engine == srv.cfg.Engine {
+			mergeEngineParams(&tr.Engine, srv.cfg.EngineParams)
 		}
-		if _, exists := tr.Engine.Params[k]; !exists {
-			tr.Engine.Params[k] = v
+	} else {
+		for i := range tr.Engines {
+			if tr.Engines[i].Name == srv.cfg.Engine {
+				mergeEngineParams(&tr.Engines[i], srv.cfg.EngineParams)
+			}
 		}
 	}
 
 	klog.Info(tr.String())
 
 	if err = validate(tr); err != nil {
-		return httpError(w, tr.Provider.Name, tr.Engine.Name, err.Error(), http.StatusBadRequest, time.Since(start))
+		return httpError(w, tr.Provider.Name, tr.EngineName(), err.Error(), http.StatusBadRequest, time.Since(start))
 	}
 
 	return tr
 }
 
+func mergeEngineParams(eng *topology.Engine, params map[string]any) {
+	for k, v := range params {
+		if eng.Params == nil {
+			eng.Params = make(map[string]any)
+		}
+		if _, exists := eng.Params[k]; !exists {
+			eng.Params[k] = v
+		}
+	}
+}
+
 func validate(tr *topology.Request) error {
 	_, exists := registry.Providers[tr.Provider.Name]
 	if !exists {
@@ -224,9 +298,19 @@ func validate(tr *topology.Request) error {
 		}
 	}
 
-	_, exists = registry.Engines[tr.Engine.Name]
+	for _, eng := range tr.GetEngines() {
+		if err := validateEngine(eng.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateEngine(name string) error {
+	_, exists := registry.Engines[name]
 	if !exists {
-		switch tr.Engine.Name {
+		switch name {
 
 		// case common.EngineSLURM, common.EngineTest:
 		// 	//nop
@@ -239,7 +323,7 @@ func validate(tr *topology.Request) error {
 		case "":
 			return fmt.Errorf("no engine given for topology request")
 		default:
-			return fmt.Errorf("unsupported engine %s", tr.Engine.Name)
+			return fmt.Errorf("unsupported engine %s", name)
 		}
 	}
 	// TODO: Validate K8s params
@@ -267,7 +351,7 @@ func writeResultResponse(uid string, w http.ResponseWriter) {
 	res := srv.async.queue.Get(uid)
 
 	switch res.Status {
-	case http.StatusOK:
+	case http.StatusOK, http.StatusMultiStatus:
 		w.WriteHeader(res.Status)
 		_, _ = w.Write(res.Ret.([]byte))
 	case http.StatusAccepted:
@@ -32,7 +32,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/NVIDIA/topograph/pkg/config"
+	reportengine "github.com/NVIDIA/topograph/pkg/engines/report"
 	"github.com/NVIDIA/topograph/pkg/test"
+	"github.com/NVIDIA/topograph/pkg/topology"
 )
 
 const (
@@ -619,6 +621,124 @@ func TestReadRequest(t *testing.T) {
 	}
 }
 
+func TestReadReportRequest(t *testing.T) {
+	srv = &HttpServer{
+		cfg: &config.Config{
+			Provider: "test",
+			Engine:   "slurm",
+			EngineParams: map[string]any{
+				"engineParam01": "test",
+			},
+		},
+	}
+
+	for _, payload := range []string{"", fmt.Sprintf(simpleSlurmPayload, "test")} {
+		r := &http.Request{
+			Method: http.MethodPost,
+			Body:   io.NopCloser(bytes.NewBuffer([]byte(payload))),
+		}
+		w := httptest.NewRecorder()
+
+		req := readEngineRequest(w, r, reportengine.NAME)
+		require.NotNil(t, req)
+		require.Equal(t, "test", req.Provider.Name)
+		require.Equal(t, reportengine.NAME, req.Engine.Name)
+		// the params of the configured slurm engine do not apply to the report engine
+		require.Empty(t, req.Engine.Params)
+	}
+
+	srv.cfg.Engine = reportengine.NAME
+	r := &http.Request{
+		Method: http.MethodPost,
+		Body:   io.NopCloser(bytes.NewBuffer([]byte(""))),
+	}
+	req := readEngineRequest(httptest.NewRecorder(), r, reportengine.NAME)
+	require.NotNil(t, req)
+	require.Equal(t, map[string]any{"engineParam01": "test"}, req.Engine.Params)
+}
+
+func TestReadFanOutRequest(t *testing.T) {
+	srv = &HttpServer{
+		cfg: &config.Config{
+			Provider: "test",
+			Engine:   "slurm",
+			EngineParams: map[string]any{
+				"engineParam01": "test",
+			},
+		},
+	}
+
+	payload := `{"engines": [{"name": "slurm"}, {"name": "graph"}]}`
+	r := &http.Request{
+		Method: http.MethodPost,
+		Body:   io.NopCloser(bytes.NewBuffer([]byte(payload))),
+	}
+	w := httptest.NewRecorder()
+
+	req := readRequest(w, r)
+	require.NotNil(t, req)
+	require.Empty(t, req.Engine.Name)
+	require.Equal(t, []topology.Engine{
+		{Name: "slurm", Params: map[string]any{"engineParam01": "test"}},
+		{Name: "graph"},
+	}, req.Engines)
+
+	// the report endpoint overrides the engines
+	r = &http.Request{
+		Method: http.MethodPost,
+		Body:   io.NopCloser(bytes.NewBuffer([]byte(payload))),
+	}
+	req = readEngineRequest(w, r, reportengine.NAME)
+	require.NotNil(t, req)
+	require.Equal(t, reportengine.NAME, req.Engine.Name)
+	require.Empty(t, req.Engines)
+}
+
+func TestExplainInvalidRequest(t *testing.T) {
+	srv = &HttpServer{
+		cfg: &config.Config{
+			Provider: "test",
+			Engine:   "graph",
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		url     string
+		payload string
+		msg     string
+	}{
+		{
+			name:    "Case 1: missing node",
+			url:     "/v1/explain",
+			payload: fmt.Sprintf(simpleSlurmPayload, "test"),
+			msg:     "must specify node\n",
+		},
+		{
+			name: "Case 2: unsupported engine",
+			url:  "/v1/explain?node=node1",
+			msg:  "explain requires the slurm or slinky engine\n",
+		},
+		{
+			name:    "Case 3: fan-out request",
+			url:     "/v1/explain?node=node1",
+			payload: `{"engines": [{"name": "slurm"}, {"name": "graph"}]}`,
+			msg:     "explain requires the slurm or slinky engine\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, tc.url, bytes.NewBuffer([]byte(tc.payload)))
+			w := httptest.NewRecorder()
+
+			explain(w, r)
+			require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+			require.Equal(t, tc.msg, w.Body.String())
+		})
+	}
+}
+
 func readInvalidRequest(t *testing.T, payload, msg string) {
 	r := &http.Request{
 		Method: http.MethodPost,
@@ -677,6 +797,34 @@ func TestReadInvalidRequest(t *testing.T) {
 			}`,
 			message: "unsupported engine mytestengine\n",
 		},
+		{
+			name: "Test validate with an invalid engine in engines",
+			payload: `{
+				"provider": {
+					"name": "test"
+				},
+				"engines": [
+					{"name": "slurm"},
+					{"name": "mytestengine"}
+				]
+			}`,
+			message: "unsupported engine mytestengine\n",
+		},
+		{
+			name: "Test validate with both engine and engines",
+			payload: `{
+				"provider": {
+					"name": "test"
+				},
+				"engine": {
+					"name": "slurm"
+				},
+				"engines": [
+					{"name": "k8s"}
+				]
+			}`,
+			message: "engine and engines are mutually exclusive\n",
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -96,6 +96,7 @@ func (q *TrailingDelayQueue) Submit(item Hashable) (string, error) {
 		// update the status only if there was no later request for the same hash
 		if currEntry, ok := q.store.Get(hash); ok && currEntry == entry {
 			if err != nil {
+				entry.Ret = data
 				entry.Status = err.Code()
 				entry.Message = err.Error()
 				klog.Errorf("HTTP %d: %s", entry.Status, entry.Message)
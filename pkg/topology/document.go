@@ -0,0 +1,309 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package topology
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	GraphAPIVersion = "topograph.nvidia.com/v1alpha1"
+	GraphKind       = "TopologyGraph"
+
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// GraphSchema is the JSON Schema of the serialized graph document.
+//
+//go:embed schema/graph-v1alpha1.schema.json
+var GraphSchema []byte
+
+// GraphDocument is the versioned interchange format of the topology graph.
+// All lists are sorted, so that two documents describing the same graph are
+// byte-for-byte identical apart from the metadata.
+type GraphDocument struct {
+	APIVersion string         `json:"apiVersion"`
+	Kind       string         `json:"kind"`
+	Metadata   GraphMetadata  `json:"metadata"`
+	Switches   []SwitchRecord `json:"switches,omitempty"`
+	Nodes      []NodeRecord   `json:"nodes,omitempty"`
+	Domains    []DomainRecord `json:"domains,omitempty"`
+	Instances  []Instance     `json:"instances,omitempty"`
+}
+
+// GraphMetadata carries the provenance of the graph.
+type GraphMetadata struct {
+	Provider  string    `json:"provider,omitempty"`
+	Timestamp time.Time `json:"timestamp,omitzero"`
+}
+
+// SwitchRecord is a network switch. Tier is the switch height above the
+// compute nodes: leaf switches are tier 1, spine switches tier 2, and so on.
+// Switches and Nodes list the IDs of the connected lower-tier vertices.
+// A vertex may be listed by more than one switch.
+type SwitchRecord struct {
+	ID       string       `json:"id"`
+	Name     string       `json:"name,omitempty"`
+	Tier     int          `json:"tier"`
+	Switches []string     `json:"switches,omitempty"`
+	Nodes    []string     `json:"nodes,omitempty"`
+	Links    []LinkRecord `json:"links,omitempty"`
+}
+
+// LinkRecord describes the physical links to a connected lower-tier vertex.
+// Rate is the data rate of a single link in Gb/s.
+type LinkRecord struct {
+	ID    string       `json:"id"`
+	Count int          `json:"count,omitempty"`
+	Ports []PortRecord `json:"ports,omitempty"`
+	Width int          `json:"width,omitempty"`
+	Speed string       `json:"speed,omitempty"`
+	Rate  float64      `json:"rate,omitempty"`
+}
+
+// PortRecord is a pair of connected ports: local on the switch, remote on the connected vertex.
+type PortRecord struct {
+	Local  int `json:"local"`
+	Remote int `json:"remote"`
+}
+
+// NodeRecord is a compute node, identified by its instance ID.
+type NodeRecord struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// DomainRecord is an accelerator domain and its member hosts.
+type DomainRecord struct {
+	Name  string       `json:"name"`
+	Hosts []DomainHost `json:"hosts"`
+}
+
+type DomainHost struct {
+	InstanceID string `json:"instance"`
+	HostName   string `json:"host"`
+}
+
+// NewGraphDocument converts the graph into its canonical document form.
+// Switches and nodes that are not connected to any switch are the top-level
+// vertices of the graph.
+func NewGraphDocument(graph *Graph, meta GraphMetadata) *GraphDocument {
+	doc := &GraphDocument{
+		APIVersion: GraphAPIVersion,
+		Kind:       GraphKind,
+		Metadata:   meta,
+	}
+	if graph == nil {
+		return doc
+	}
+
+	if graph.Tiers != nil {
+		switches := make(map[string]*SwitchRecord)
+		nodes := make(map[string]*NodeRecord)
+		tiers := make(map[string]int)
+		for _, w := range graph.Tiers.Vertices {
+			addVertexRecords(w, switches, nodes, tiers)
+		}
+		for _, id := range slices.Sorted(maps.Keys(switches)) {
+			doc.Switches = append(doc.Switches, *switches[id])
+		}
+		for _, id := range slices.Sorted(maps.Keys(nodes)) {
+			doc.Nodes = append(doc.Nodes, *nodes[id])
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(graph.Domains)) {
+		domain := graph.Domains[name]
+		rec := DomainRecord{Name: name, Hosts: make([]DomainHost, 0, len(domain))}
+		for _, host := range slices.Sorted(maps.Keys(domain)) {
+			rec.Hosts = append(rec.Hosts, DomainHost{InstanceID: domain[host].InstanceID, HostName: host})
+		}
+		doc.Domains = append(doc.Domains, rec)
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(graph.Instances)) {
+		doc.Instances = append(doc.Instances, graph.Instances[id])
+	}
+
+	return doc
+}
+
+// addVertexRecords records the vertex and its descendants, and returns the vertex tier
+func addVertexRecords(v *Vertex, switches map[string]*SwitchRecord, nodes map[string]*NodeRecord, tiers map[string]int) int {
+	if len(v.Vertices) == 0 {
+		nodes[v.ID] = &NodeRecord{ID: v.ID, Name: v.Name}
+		return 0
+	}
+	if tier, ok := tiers[v.ID]; ok {
+		return tier
+	}
+
+	sw := &SwitchRecord{ID: v.ID, Name: v.Name}
+	switches[v.ID] = sw
+	for _, w := range v.Vertices {
+		tier := addVertexRecords(w, switches, nodes, tiers)
+		if tier+1 > sw.Tier {
+			sw.Tier = tier + 1
+		}
+		if tier == 0 {
+			sw.Nodes = append(sw.Nodes, w.ID)
+		} else {
+			sw.Switches = append(sw.Switches, w.ID)
+		}
+	}
+	slices.Sort(sw.Switches)
+	slices.Sort(sw.Nodes)
+	for _, id := range slices.Sorted(maps.Keys(v.Links)) {
+		if link := v.Links[id]; link != nil {
+			rec := LinkRecord{ID: id, Count: link.Count, Width: link.Width, Speed: link.Speed, Rate: link.Rate}
+			for _, port := range link.Ports {
+				rec.Ports = append(rec.Ports, PortRecord{Local: port.Local, Remote: port.Remote})
+			}
+			sw.Links = append(sw.Links, rec)
+		}
+	}
+	tiers[v.ID] = sw.Tier
+
+	return sw.Tier
+}
+
+// ToGraph reconstructs the topology graph from the document.
+func (doc *GraphDocument) ToGraph() (*Graph, error) {
+	if doc.APIVersion != GraphAPIVersion {
+		return nil, fmt.Errorf("unsupported graph apiVersion %q", doc.APIVersion)
+	}
+	if doc.Kind != GraphKind {
+		return nil, fmt.Errorf("unsupported graph kind %q", doc.Kind)
+	}
+
+	graph := &Graph{}
+
+	if len(doc.Switches) != 0 || len(doc.Nodes) != 0 {
+		vertices := make(map[string]*Vertex)
+		for _, node := range doc.Nodes {
+			if _, ok := vertices[node.ID]; ok {
+				return nil, fmt.Errorf("duplicate vertex ID %q", node.ID)
+			}
+			vertices[node.ID] = &Vertex{ID: node.ID, Name: node.Name}
+		}
+		for _, sw := range doc.Switches {
+			if sw.Tier < 1 {
+				return nil, fmt.Errorf("switch %q has invalid tier %d", sw.ID, sw.Tier)
+			}
+			if _, ok := vertices[sw.ID]; ok {
+				return nil, fmt.Errorf("duplicate vertex ID %q", sw.ID)
+			}
+			vertices[sw.ID] = &Vertex{ID: sw.ID, Name: sw.Name, Vertices: make(map[string]*Vertex)}
+		}
+
+		tiers := make(map[string]int)
+		for _, sw := range doc.Switches {
+			tiers[sw.ID] = sw.Tier
+		}
+
+		children := make(map[string]bool)
+		for _, sw := range doc.Switches {
+			v := vertices[sw.ID]
+			for _, id := range sw.Nodes {
+				w, ok := vertices[id]
+				if !ok || w.Vertices != nil {
+					return nil, fmt.Errorf("switch %q refers to unknown node %q", sw.ID, id)
+				}
+				v.Vertices[id] = w
+				children[id] = true
+			}
+			for _, id := range sw.Switches {
+				w, ok := vertices[id]
+				if !ok || w.Vertices == nil {
+					return nil, fmt.Errorf("switch %q refers to unknown switch %q", sw.ID, id)
+				}
+				if tiers[id] >= sw.Tier {
+					return nil, fmt.Errorf("switch %q at tier %d refers to switch %q at tier %d", sw.ID, sw.Tier, id, tiers[id])
+				}
+				v.Vertices[id] = w
+				children[id] = true
+			}
+			if len(v.Vertices) == 0 {
+				return nil, fmt.Errorf("switch %q has no connected vertices", sw.ID)
+			}
+			for _, link := range sw.Links {
+				if _, ok := v.Vertices[link.ID]; !ok {
+					return nil, fmt.Errorf("switch %q has link to unconnected vertex %q", sw.ID, link.ID)
+				}
+				if v.Links == nil {
+					v.Links = make(map[string]*Link)
+				}
+				l := &Link{Count: link.Count, Width: link.Width, Speed: link.Speed, Rate: link.Rate}
+				for _, port := range link.Ports {
+					l.Ports = append(l.Ports, Port{Local: port.Local, Remote: port.Remote})
+				}
+				v.Links[link.ID] = l
+			}
+		}
+
+		root := &Vertex{Vertices: make(map[string]*Vertex)}
+		for id, v := range vertices {
+			if !children[id] {
+				root.Vertices[id] = v
+			}
+		}
+		graph.Tiers = root
+	}
+
+	if len(doc.Domains) != 0 {
+		graph.Domains = NewDomainMap()
+		for _, domain := range doc.Domains {
+			for _, host := range domain.Hosts {
+				graph.Domains.AddHost(domain.Name, host.InstanceID, host.HostName)
+			}
+		}
+	}
+
+	if len(doc.Instances) != 0 {
+		graph.Instances = make(map[string]Instance, len(doc.Instances))
+		for _, inst := range doc.Instances {
+			graph.Instances[inst.ID] = inst
+		}
+	}
+
+	return graph, nil
+}
+
+// Marshal serializes the graph in the given format (json or yaml).
+func Marshal(graph *Graph, meta GraphMetadata, format string) ([]byte, error) {
+	doc := NewGraphDocument(graph, meta)
+	switch format {
+	case FormatJSON, "":
+		return json.MarshalIndent(doc, "", "  ")
+	case FormatYAML:
+		return yaml.Marshal(doc)
+	default:
+		return nil, fmt.Errorf("unsupported graph format %q", format)
+	}
+}
+
+// Unmarshal parses a serialized graph document in either JSON or YAML format.
+func Unmarshal(data []byte) (*Graph, *GraphMetadata, error) {
+	var doc GraphDocument
+	if err := yaml.UnmarshalStrict(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse graph document: %v", err)
+	}
+
+	graph, err := doc.ToGraph()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return graph, &doc.Metadata, nil
+}
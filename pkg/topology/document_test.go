@@ -0,0 +1,196 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package topology
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testGraphYAML = `apiVersion: topograph.nvidia.com/v1alpha1
+domains:
+- hosts:
+  - host: node1
+    instance: i-001
+  name: acc-111111
+- hosts:
+  - host: node2
+    instance: i-002
+  name: acc-222222
+kind: TopologyGraph
+metadata:
+  provider: test
+  timestamp: "2026-01-02T03:04:05Z"
+nodes:
+- id: i-001
+  name: node1
+- id: i-002
+  name: node2
+- id: i-003
+  name: node3
+- id: i-004
+  name: node4
+- id: i-cpu
+  name: node5
+switches:
+- id: nn-11111111
+  nodes:
+  - i-001
+  tier: 1
+- id: nn-22222222
+  nodes:
+  - i-002
+  tier: 1
+- id: nn-33333333
+  nodes:
+  - i-003
+  tier: 1
+- id: nn-44444444
+  nodes:
+  - i-004
+  tier: 1
+- id: nn-55555555
+  switches:
+  - nn-11111111
+  - nn-22222222
+  tier: 2
+- id: nn-66666666
+  switches:
+  - nn-33333333
+  - nn-44444444
+  tier: 2
+- id: nn-77777777
+  switches:
+  - nn-55555555
+  - nn-66666666
+  tier: 3
+- id: no-topology
+  nodes:
+  - i-cpu
+  tier: 1
+`
+
+func TestGraphDocumentRoundTrip(t *testing.T) {
+	topo := NewClusterTopology()
+	for _, inst := range instances {
+		topo.Append(inst)
+	}
+	graph := topo.ToThreeTierGraph("test", []ComputeInstances{{Instances: i2n}}, 0, false)
+	meta := GraphMetadata{Provider: "test", Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	data, err := Marshal(graph, meta, FormatYAML)
+	require.NoError(t, err)
+	require.Equal(t, testGraphYAML, string(data))
+
+	for _, format := range []string{FormatJSON, FormatYAML} {
+		data, err := Marshal(graph, meta, format)
+		require.NoError(t, err)
+
+		out, outMeta, err := Unmarshal(data)
+		require.NoError(t, err)
+		require.Equal(t, meta.Provider, outMeta.Provider)
+		require.True(t, meta.Timestamp.Equal(outMeta.Timestamp))
+		require.Equal(t, graph, out)
+	}
+}
+
+func TestGraphDocumentInstances(t *testing.T) {
+	graph := &Graph{
+		Instances: map[string]Instance{
+			"i-2": {ID: "i-2", NetworkLayers: []string{"leaf"}},
+			"i-1": {ID: "i-1", NetworkLayers: []string{"leaf"}, Labels: map[string]string{KeyTopologyAccelerator: "nvl"}},
+		},
+	}
+
+	data, err := Marshal(graph, GraphMetadata{}, FormatJSON)
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Equal(t, map[string]any{}, doc["metadata"])
+
+	out, _, err := Unmarshal(data)
+	require.NoError(t, err)
+	require.Equal(t, graph, out)
+}
+
+func TestGraphDocumentErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{
+			name: "Case 1: wrong version",
+			data: `{"apiVersion":"v0","kind":"TopologyGraph","metadata":{}}`,
+			err:  `unsupported graph apiVersion "v0"`,
+		},
+		{
+			name: "Case 2: wrong kind",
+			data: `{"apiVersion":"topograph.nvidia.com/v1alpha1","kind":"Graph","metadata":{}}`,
+			err:  `unsupported graph kind "Graph"`,
+		},
+		{
+			name: "Case 3: unknown field",
+			data: `{"apiVersion":"topograph.nvidia.com/v1alpha1","kind":"TopologyGraph","metadata":{},"tiers":[]}`,
+			err:  `failed to parse graph document`,
+		},
+		{
+			name: "Case 4: duplicate ID",
+			data: `{"apiVersion":"topograph.nvidia.com/v1alpha1","kind":"TopologyGraph","metadata":{},
+"nodes":[{"id":"n1","name":"node1"}],"switches":[{"id":"n1","tier":1,"nodes":["n1"]}]}`,
+			err: `duplicate vertex ID "n1"`,
+		},
+		{
+			name: "Case 5: unknown node",
+			data: `{"apiVersion":"topograph.nvidia.com/v1alpha1","kind":"TopologyGraph","metadata":{},
+"switches":[{"id":"s1","tier":1,"nodes":["n1"]}]}`,
+			err: `switch "s1" refers to unknown node "n1"`,
+		},
+		{
+			name: "Case 6: tier loop",
+			data: `{"apiVersion":"topograph.nvidia.com/v1alpha1","kind":"TopologyGraph","metadata":{},
+"nodes":[{"id":"n1","name":"node1"}],
+"switches":[{"id":"s1","tier":1,"nodes":["n1"],"switches":["s2"]},{"id":"s2","tier":2,"switches":["s1"]}]}`,
+			err: `switch "s1" at tier 1 refers to switch "s2" at tier 2`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := Unmarshal([]byte(tc.data))
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestGraphSchema(t *testing.T) {
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(GraphSchema, &schema))
+	require.Equal(t, GraphKind, schema["title"])
+}
+
+func TestGraphDocumentMultiParent(t *testing.T) {
+	graph := getClosGraph()
+	graph.Tiers.Vertices["S3"].Links["L3"] = &Link{
+		Count: 2,
+		Ports: []Port{{Local: 1, Remote: 31}, {Local: 2, Remote: 32}},
+		Width: 4,
+		Speed: "NDR",
+		Rate:  400,
+	}
+
+	data, err := Marshal(graph, GraphMetadata{}, FormatJSON)
+	require.NoError(t, err)
+
+	out, _, err := Unmarshal(data)
+	require.NoError(t, err)
+	require.Equal(t, graph, out)
+	require.False(t, IsTree(out.Tiers))
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package topology
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// SwitchLinks summarizes the links of a network switch
+type SwitchLinks struct {
+	ID   string
+	Name string
+	// Tier is the switch height above the compute nodes, starting from 1 for leaf switches
+	Tier int
+	// Downlinks is the number of links to lower-tier switches and compute nodes
+	Downlinks int
+	// Uplinks is the number of links to upper-tier switches
+	Uplinks int
+	// ExpectedUplinks is the largest number of uplinks among the switches of the same tier
+	ExpectedUplinks int
+	// DownlinkRate and UplinkRate are the aggregate data rates in Gb/s, or 0 if unknown
+	DownlinkRate float64
+	UplinkRate   float64
+}
+
+// Oversubscription returns the ratio of downlink to uplink capacity.
+// The data rates are used when known for all the links, and the link counts otherwise.
+// Returns 0 for switches without uplinks.
+func (s *SwitchLinks) Oversubscription() float64 {
+	if s.Uplinks == 0 {
+		return 0
+	}
+	if s.DownlinkRate > 0 && s.UplinkRate > 0 {
+		return s.DownlinkRate / s.UplinkRate
+	}
+	return float64(s.Downlinks) / float64(s.Uplinks)
+}
+
+// HalfCabled reports whether the switch has fewer uplinks than the other switches of its tier
+func (s *SwitchLinks) HalfCabled() bool {
+	return s.Uplinks < s.ExpectedUplinks
+}
+
+// GetSwitchLinks returns the link summary of every switch below the root, keyed by switch ID.
+// A connection without link details counts as a single link of unknown rate.
+func GetSwitchLinks(root *Vertex) map[string]*SwitchLinks {
+	res := make(map[string]*SwitchLinks)
+	if root == nil {
+		return res
+	}
+
+	// switches with some link rates unknown
+	downUnknown := make(map[string]bool)
+	upUnknown := make(map[string]bool)
+	var visit func(*Vertex) int
+	visit = func(v *Vertex) int {
+		if len(v.Vertices) == 0 {
+			return 0
+		}
+		if sw, ok := res[v.ID]; ok {
+			return sw.Tier
+		}
+		sw := &SwitchLinks{ID: v.ID, Name: v.Name}
+		res[v.ID] = sw
+		for id, w := range v.Vertices {
+			sw.Tier = max(sw.Tier, visit(w)+1)
+
+			link := v.Links[id]
+			if link == nil {
+				link = &Link{Count: 1}
+			}
+			sw.Downlinks += link.Count
+			sw.DownlinkRate += link.Bandwidth()
+			if link.Rate == 0 {
+				downUnknown[v.ID] = true
+			}
+
+			if child, ok := res[w.ID]; ok {
+				child.Uplinks += link.Count
+				child.UplinkRate += link.Bandwidth()
+				if link.Rate == 0 {
+					upUnknown[w.ID] = true
+				}
+			}
+		}
+		return sw.Tier
+	}
+	for _, id := range slices.Sorted(maps.Keys(root.Vertices)) {
+		// nodes without topology are not connected to a physical switch
+		if id != NoTopology {
+			visit(root.Vertices[id])
+		}
+	}
+
+	expected := make(map[int]int) // tier : max uplinks
+	for id, sw := range res {
+		if downUnknown[id] {
+			sw.DownlinkRate = 0
+		}
+		if upUnknown[id] {
+			sw.UplinkRate = 0
+		}
+		expected[sw.Tier] = max(expected[sw.Tier], sw.Uplinks)
+	}
+	for _, sw := range res {
+		sw.ExpectedUplinks = expected[sw.Tier]
+	}
+
+	return res
+}
+
+// GetHalfCabledSwitches returns the switches having fewer uplinks than
+// the other switches of their tier, sorted by tier and ID
+func GetHalfCabledSwitches(root *Vertex) []*SwitchLinks {
+	var res []*SwitchLinks
+	for _, sw := range GetSwitchLinks(root) {
+		if sw.HalfCabled() {
+			res = append(res, sw)
+		}
+	}
+	slices.SortFunc(res, func(a, b *SwitchLinks) int {
+		if a.Tier != b.Tier {
+			return a.Tier - b.Tier
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return res
+}
+
+// WarnHalfCabledSwitches logs the switches having fewer uplinks than
+// the other switches of their tier
+func WarnHalfCabledSwitches(root *Vertex) {
+	for _, sw := range GetHalfCabledSwitches(root) {
+		klog.Warningf("switch %q at tier %d has %d uplinks, expected %d", sw.ID, sw.Tier, sw.Uplinks, sw.ExpectedUplinks)
+	}
+}
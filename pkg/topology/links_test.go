@@ -0,0 +1,82 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package topology
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkAdd(t *testing.T) {
+	link := &Link{}
+	link.Add(&Link{Count: 1, Ports: []Port{{Local: 1, Remote: 2}}, Width: 4, Speed: "NDR", Rate: 400})
+	link.Add(&Link{Count: 1, Ports: []Port{{Local: 3, Remote: 4}}, Width: 4, Speed: "NDR", Rate: 400})
+	require.Equal(t, &Link{Count: 2, Ports: []Port{{Local: 1, Remote: 2}, {Local: 3, Remote: 4}}, Width: 4, Speed: "NDR", Rate: 400}, link)
+	require.Equal(t, 800.0, link.Bandwidth())
+
+	// links of different speed
+	link.Add(&Link{Count: 1, Width: 4, Speed: "HDR", Rate: 200})
+	require.Equal(t, 3, link.Count)
+	require.Equal(t, 0.0, link.Rate)
+	require.Equal(t, 0.0, link.Bandwidth())
+}
+
+func TestGetSwitchLinks(t *testing.T) {
+	stats := GetSwitchLinks(getClosGraph().Tiers)
+
+	// leaf switches without link details count a single link per compute node
+	require.Equal(t, &SwitchLinks{ID: "L1", Tier: 1, Downlinks: 1, Uplinks: 4, ExpectedUplinks: 5}, stats["L1"])
+	require.Equal(t, &SwitchLinks{ID: "L2", Tier: 1, Downlinks: 1, Uplinks: 5, ExpectedUplinks: 5}, stats["L2"])
+	require.Equal(t, &SwitchLinks{ID: "L3", Tier: 1, Downlinks: 1, Uplinks: 4, ExpectedUplinks: 5}, stats["L3"])
+	require.Equal(t, &SwitchLinks{ID: "S3", Tier: 2, Downlinks: 5}, stats["S3"])
+	require.Equal(t, 0.25, stats["L1"].Oversubscription())
+	require.Equal(t, 0.0, stats["S3"].Oversubscription())
+
+	half := GetHalfCabledSwitches(getClosGraph().Tiers)
+	require.Len(t, half, 2)
+	require.Equal(t, "L1", half[0].ID)
+	require.Equal(t, "L3", half[1].ID)
+
+	require.Empty(t, GetSwitchLinks(nil))
+}
+
+func TestOversubscription(t *testing.T) {
+	//
+	//      S1
+	//      |  1 x 400G
+	//      L1
+	//     / \  2 x 400G each
+	//   n1   n2
+	//
+	n1 := &Vertex{ID: "n1"}
+	n2 := &Vertex{ID: "n2"}
+	l1 := &Vertex{
+		ID:       "L1",
+		Vertices: map[string]*Vertex{"n1": n1, "n2": n2},
+		Links: map[string]*Link{
+			"n1": {Count: 2, Rate: 400},
+			"n2": {Count: 2, Rate: 400},
+		},
+	}
+	s1 := &Vertex{
+		ID:       "S1",
+		Vertices: map[string]*Vertex{"L1": l1},
+		Links:    map[string]*Link{"L1": {Count: 1, Rate: 400}},
+	}
+	root := &Vertex{Vertices: map[string]*Vertex{"S1": s1}}
+
+	stats := GetSwitchLinks(root)
+	require.Equal(t, 4.0, stats["L1"].Oversubscription())
+	require.Equal(t, 1600.0, stats["L1"].DownlinkRate)
+	require.Equal(t, 400.0, stats["L1"].UplinkRate)
+
+	// rate is unknown for some links: fall back to link counts
+	l1.Links["n2"].Rate = 0
+	stats = GetSwitchLinks(root)
+	require.Equal(t, 0.0, stats["L1"].DownlinkRate)
+	require.Equal(t, 4.0, stats["L1"].Oversubscription())
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package topology
+
+import (
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// Naming strategies
+const (
+	// NamingID names switches by their provider IDs and blocks by their accelerator domains
+	NamingID = "id"
+	// NamingName names switches by their provider names, falling back to the IDs
+	NamingName = "name"
+	// NamingHash names switches and blocks by a stable hash of their IDs
+	NamingHash = "hash"
+)
+
+var (
+	namingPresets = map[string]map[bool]string{
+		NamingID:   {true: "{id}", false: "{id}"},
+		NamingName: {true: "{name}", false: "{id}"},
+		NamingHash: {true: "switch-{hash}", false: "block-{hash}"},
+	}
+
+	placeholderRegexp = regexp.MustCompile(`\{[^{}]*\}`)
+
+	namingPlaceholders = map[string]bool{"{tier}": true, "{index}": true, "{id}": true, "{name}": true, "{hash}": true}
+)
+
+// Naming defines how the switches and blocks are named in the engine outputs.
+// Each field is a naming strategy: "id", "name", "hash", or a template with the placeholders
+//   - {tier}: the switch height above the compute nodes, starting from 1 for leaf switches
+//   - {index}: the 1-based position of the switch within its tier ordered by ID,
+//     or of the block in the block topology
+//   - {id}: the switch ID, or the accelerator domain of the block with a "-<n>" suffix
+//     for the n-th block of a domain split across several base blocks
+//   - {name}: the provider name of the switch falling back to its ID, or the accelerator domain of the block
+//   - {hash}: 8 hex digits of the FNV-1a hash of {id}
+//
+// Names derived from {id}, {name} or {hash} stay the same when other switches or domains are added.
+// An empty strategy keeps the default names of the engine.
+type Naming struct {
+	Switches string `mapstructure:"switches"`
+	Blocks   string `mapstructure:"blocks"`
+}
+
+// Validate checks the naming strategies
+func (n *Naming) Validate() error {
+	if err := validateNaming(n.Switches); err != nil {
+		return fmt.Errorf("invalid switch naming: %v", err)
+	}
+	if err := validateNaming(n.Blocks); err != nil {
+		return fmt.Errorf("invalid block naming: %v", err)
+	}
+	return nil
+}
+
+func validateNaming(strategy string) error {
+	if len(strategy) == 0 {
+		return nil
+	}
+	if _, ok := namingPresets[strategy]; ok {
+		return nil
+	}
+
+	placeholders := placeholderRegexp.FindAllString(strategy, -1)
+	if len(placeholders) == 0 {
+		return fmt.Errorf("%q is neither a naming strategy nor a template", strategy)
+	}
+	unique := false
+	for _, ph := range placeholders {
+		if !namingPlaceholders[ph] {
+			return fmt.Errorf("unsupported placeholder %s in template %q", ph, strategy)
+		}
+		if ph != "{tier}" {
+			unique = true
+		}
+	}
+	if !unique {
+		return fmt.Errorf("template %q must contain one of {index}, {id}, {name} or {hash}", strategy)
+	}
+	return nil
+}
+
+func namingTemplate(strategy string, switches bool) string {
+	if preset, ok := namingPresets[strategy]; ok {
+		return preset[switches]
+	}
+	return strategy
+}
+
+func expandNaming(template string, values map[string]string) string {
+	return placeholderRegexp.ReplaceAllStringFunc(template, func(ph string) string {
+		return values[ph]
+	})
+}
+
+func namingHash(id string) string {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// SwitchNames returns the names of the switches in the tree keyed by switch ID,
+// or nil for the default strategy. The NoTopology switch keeps its ID.
+func (n *Naming) SwitchNames(root *Vertex) (map[string]string, error) {
+	if root == nil || len(n.Switches) == 0 {
+		return nil, nil
+	}
+
+	switches := make(map[string]*Vertex)
+	heights := make(map[string]int)
+	var height func(*Vertex) int
+	height = func(v *Vertex) int {
+		if len(v.Vertices) == 0 {
+			return 0
+		}
+		if h, ok := heights[v.ID]; ok {
+			return h
+		}
+		h := 0
+		for _, w := range v.Vertices {
+			h = max(h, height(w)+1)
+		}
+		heights[v.ID] = h
+		switches[v.ID] = v
+		return h
+	}
+	for _, w := range root.Vertices {
+		height(w)
+	}
+
+	template := namingTemplate(n.Switches, true)
+	names := make(map[string]string, len(switches))
+	indices := make(map[int]int)
+	owners := make(map[string]string)
+	for _, id := range slices.Sorted(maps.Keys(switches)) {
+		if id == NoTopology {
+			names[id] = id
+			continue
+		}
+		v := switches[id]
+		tier := heights[id]
+		indices[tier]++
+		name := v.Name
+		if len(name) == 0 {
+			name = id
+		}
+		names[id] = expandNaming(template, map[string]string{
+			"{tier}":  strconv.Itoa(tier),
+			"{index}": strconv.Itoa(indices[tier]),
+			"{id}":    id,
+			"{name}":  name,
+			"{hash}":  namingHash(id),
+		})
+		if owner, ok := owners[names[id]]; ok {
+			return nil, fmt.Errorf("switches %q and %q have the same name %q", owner, id, names[id])
+		}
+		owners[names[id]] = id
+	}
+
+	return names, nil
+}
+
+// BlockNames returns the names of the blocks given the accelerator domains of the blocks
+// in the order of the block topology, or nil for the default strategy.
+// Blocks without a domain, such as the padding blocks, get the default name "block<index>".
+func (n *Naming) BlockNames(domains []string) ([]string, error) {
+	if len(n.Blocks) == 0 {
+		return nil, nil
+	}
+
+	template := namingTemplate(n.Blocks, false)
+	names := make([]string, 0, len(domains))
+	parts := make(map[string]int)
+	owners := make(map[string]int)
+	for i, domain := range domains {
+		var name string
+		if len(domain) == 0 {
+			name = fmt.Sprintf("block%03d", i+1)
+		} else {
+			parts[domain]++
+			id := domain
+			if parts[domain] > 1 {
+				id = fmt.Sprintf("%s-%d", domain, parts[domain])
+			}
+			name = expandNaming(template, map[string]string{
+				"{tier}":  "",
+				"{index}": strconv.Itoa(i + 1),
+				"{id}":    id,
+				"{name}":  domain,
+				"{hash}":  namingHash(id),
+			})
+		}
+		if j, ok := owners[name]; ok {
+			return nil, fmt.Errorf("blocks %d and %d have the same name %q", j+1, i+1, name)
+		}
+		owners[name] = i
+		names = append(names, name)
+	}
+
+	return names, nil
+}
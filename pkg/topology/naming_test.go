@@ -0,0 +1,129 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package topology
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamingValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		naming Naming
+		err    string
+	}{
+		{
+			name: "Case 1: default naming",
+		},
+		{
+			name:   "Case 2: strategies and templates",
+			naming: Naming{Switches: NamingName, Blocks: "nvl-{hash}"},
+		},
+		{
+			name:   "Case 3: unknown strategy",
+			naming: Naming{Switches: "random"},
+			err:    `invalid switch naming: "random" is neither a naming strategy nor a template`,
+		},
+		{
+			name:   "Case 4: unsupported placeholder",
+			naming: Naming{Blocks: "{rack}-{index}"},
+			err:    `invalid block naming: unsupported placeholder {rack} in template "{rack}-{index}"`,
+		},
+		{
+			name:   "Case 5: template without unique placeholder",
+			naming: Naming{Switches: "tier{tier}"},
+			err:    `invalid switch naming: template "tier{tier}" must contain one of {index}, {id}, {name} or {hash}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.naming.Validate()
+			if len(tc.err) != 0 {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSwitchNames(t *testing.T) {
+	//
+	//        core
+	//       /    \
+	//    spine1  spine2
+	//      |       |
+	//    leafB   leafA
+	//      |       |
+	//     n1      n2
+	//
+	leafA := &Vertex{ID: "leafA", Name: "rack-a", Vertices: map[string]*Vertex{"n2": {ID: "n2", Name: "node2"}}}
+	leafB := &Vertex{ID: "leafB", Vertices: map[string]*Vertex{"n1": {ID: "n1", Name: "node1"}}}
+	spine1 := &Vertex{ID: "spine1", Vertices: map[string]*Vertex{"leafB": leafB}}
+	spine2 := &Vertex{ID: "spine2", Vertices: map[string]*Vertex{"leafA": leafA}}
+	core := &Vertex{ID: "core", Vertices: map[string]*Vertex{"spine1": spine1, "spine2": spine2}}
+	noTopo := &Vertex{ID: NoTopology, Vertices: map[string]*Vertex{"n3": {ID: "n3", Name: "node3"}}}
+	root := &Vertex{Vertices: map[string]*Vertex{"core": core, NoTopology: noTopo}}
+
+	names, err := (&Naming{}).SwitchNames(root)
+	require.NoError(t, err)
+	require.Nil(t, names)
+
+	names, err = (&Naming{Switches: "{tier}-{index}"}).SwitchNames(root)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"core":     "3-1",
+		"spine1":   "2-1",
+		"spine2":   "2-2",
+		"leafA":    "1-1",
+		"leafB":    "1-2",
+		NoTopology: NoTopology,
+	}, names)
+
+	names, err = (&Naming{Switches: NamingName}).SwitchNames(root)
+	require.NoError(t, err)
+	require.Equal(t, "rack-a", names["leafA"])
+	require.Equal(t, "leafB", names["leafB"])
+
+	names, err = (&Naming{Switches: NamingHash}).SwitchNames(root)
+	require.NoError(t, err)
+	require.Equal(t, "switch-"+namingHash("leafA"), names["leafA"])
+	require.Len(t, names["leafA"], len("switch-")+8)
+
+	// hash names do not depend on the other switches
+	delete(spine1.Vertices, "leafB")
+	spine1.Vertices["n1"] = &Vertex{ID: "n1", Name: "node1"}
+	renamed, err := (&Naming{Switches: NamingHash}).SwitchNames(root)
+	require.NoError(t, err)
+	require.Equal(t, names["leafA"], renamed["leafA"])
+	require.Equal(t, names["spine2"], renamed["spine2"])
+}
+
+func TestBlockNames(t *testing.T) {
+	domains := []string{"nvl1", "nvl1", "", "nvl2"}
+
+	names, err := (&Naming{}).BlockNames(domains)
+	require.NoError(t, err)
+	require.Nil(t, names)
+
+	names, err = (&Naming{Blocks: NamingID}).BlockNames(domains)
+	require.NoError(t, err)
+	require.Equal(t, []string{"nvl1", "nvl1-2", "block003", "nvl2"}, names)
+
+	names, err = (&Naming{Blocks: "blk{index}-{name}"}).BlockNames(domains)
+	require.NoError(t, err)
+	require.Equal(t, []string{"blk1-nvl1", "blk2-nvl1", "block003", "blk4-nvl2"}, names)
+
+	names, err = (&Naming{Blocks: NamingHash}).BlockNames(domains)
+	require.NoError(t, err)
+	require.Equal(t, "block-"+namingHash("nvl1-2"), names[1])
+
+	_, err = (&Naming{Blocks: "{name}"}).BlockNames(domains)
+	require.EqualError(t, err, `blocks 1 and 2 have the same name "nvl1"`)
+}
@@ -25,9 +25,11 @@ import (
 )
 
 type Request struct {
-	Provider Provider           `json:"provider"`
-	Engine   Engine             `json:"engine"`
-	Nodes    []ComputeInstances `json:"nodes"`
+	Provider Provider `json:"provider"`
+	Engine   Engine   `json:"engine"`
+	// Engines fans out the request to several engines; mutually exclusive with Engine
+	Engines []Engine           `json:"engines,omitempty"`
+	Nodes   []ComputeInstances `json:"nodes"`
 }
 
 type Provider struct {
@@ -41,6 +43,14 @@ type Engine struct {
 	Params map[string]any `json:"params"`
 }
 
+// EngineResult is the output of one engine of a fan-out request
+type EngineResult struct {
+	Engine string `json:"engine"`
+	Status int    `json:"status"`
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
 type ComputeInstances struct {
 	Region    string            `json:"region"`
 	Instances map[string]string `json:"instances"` // <instance ID>:<node name> map
@@ -59,8 +69,10 @@ func (p *Request) String() string {
 	fmt.Fprintf(&sb, "  Provider:%s\n", spacer(p.Provider.Name))
 	sb.WriteString(map2string(p.Provider.Creds, "  Credentials", true, "\n"))
 	sb.WriteString(map2string(p.Provider.Params, "  Parameters", false, "\n"))
-	fmt.Fprintf(&sb, "  Engine:%s\n", spacer(p.Engine.Name))
-	sb.WriteString(map2string(p.Engine.Params, "  Parameters", false, "\n"))
+	for _, eng := range p.GetEngines() {
+		fmt.Fprintf(&sb, "  Engine:%s\n", spacer(eng.Name))
+		sb.WriteString(map2string(eng.Params, "  Parameters", false, "\n"))
+	}
 	sb.WriteString("  Nodes:")
 	for _, nodes := range p.Nodes {
 		sb.WriteByte(' ')
@@ -70,6 +82,26 @@ func (p *Request) String() string {
 	return sb.String()
 }
 
+// GetEngines returns the engines of the request
+func (p *Request) GetEngines() []Engine {
+	if len(p.Engines) != 0 {
+		return p.Engines
+	}
+	return []Engine{p.Engine}
+}
+
+// EngineName returns the engine name, or the comma-separated engine names of a fan-out request
+func (p *Request) EngineName() string {
+	if len(p.Engines) == 0 {
+		return p.Engine.Name
+	}
+	names := make([]string, 0, len(p.Engines))
+	for _, eng := range p.Engines {
+		names = append(names, eng.Name)
+	}
+	return strings.Join(names, ",")
+}
+
 func GetTopologyRequest(body []byte) (*Request, error) {
 	var payload Request
 
@@ -151,6 +183,9 @@ func (p *Request) Hash() (string, error) {
 			Params: p.Engine.Params,
 		},
 	}
+	for _, eng := range p.Engines {
+		dataToHash.Engines = append(dataToHash.Engines, Engine{Name: eng.Name, Params: eng.Params})
+	}
 	return GetHash(dataToHash)
 }
 
@@ -142,6 +142,44 @@ func TestPayload(t *testing.T) {
   Engine: slurm
   Parameters: [blockSizes:[30 120] plugin:topology/block reconfigure:true]
   Nodes: region1: [instance1:node1 instance2:node2 instance3:node3] region2: [instance4:node4 instance5:node5 instance6:node6]
+`,
+		},
+		{
+			name: "Case 4: multiple engines",
+			input: `
+{
+  "provider": {
+    "name": "aws"
+  },
+  "engines": [
+    {
+      "name": "slurm",
+      "params": {
+        "topologyConfigPath": "/etc/slurm/topology.conf"
+      }
+    },
+    {
+      "name": "k8s"
+    }
+  ]
+}
+`,
+			payload: &Request{
+				Provider: Provider{Name: "aws"},
+				Engines: []Engine{
+					{Name: "slurm", Params: map[string]any{KeyTopoConfigPath: "/etc/slurm/topology.conf"}},
+					{Name: "k8s"},
+				},
+			},
+			print: `TopologyRequest:
+  Provider: aws
+  Credentials: []
+  Parameters: []
+  Engine: slurm
+  Parameters: [topologyConfigPath:/etc/slurm/topology.conf]
+  Engine: k8s
+  Parameters: []
+  Nodes:
 `,
 		},
 	}
@@ -177,3 +215,19 @@ func TestGetNodeNames(t *testing.T) {
 	require.ElementsMatch(t, nodeList, GetNodeNameList(cis))
 	require.Equal(t, nodeMap, GetNodeNameMap(cis))
 }
+
+func TestRequestEngines(t *testing.T) {
+	single := &Request{Engine: Engine{Name: "slurm"}}
+	require.Equal(t, []Engine{{Name: "slurm"}}, single.GetEngines())
+	require.Equal(t, "slurm", single.EngineName())
+
+	multi := &Request{Engines: []Engine{{Name: "slurm"}, {Name: "k8s"}}}
+	require.Equal(t, []Engine{{Name: "slurm"}, {Name: "k8s"}}, multi.GetEngines())
+	require.Equal(t, "slurm,k8s", multi.EngineName())
+
+	h1, err := single.Hash()
+	require.NoError(t, err)
+	h2, err := multi.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, h1, h2)
+}
@@ -58,6 +58,7 @@ const (
 	KeyConfigMapPlugin            = "topograph.nvidia.com/plugin"
 	KeyConfigMapBlockSizes        = "topograph.nvidia.com/block-sizes"
 	KeyConfigMapNamespace         = "topograph.nvidia.com/slurm-namespace"
+	KeyConfigMapIDMap             = "topograph.nvidia.com/id-map"
 
 	//Slinky specific annotations and labels
 	KeySlinkyTopologySpec = "topology.slinky.slurm.net/spec"
@@ -79,10 +80,54 @@ type Graph struct {
 // - Name is a compute node name
 // - ID is an CSP defined instance ID of switches and compute nodes
 // - Vertices is a list of connected compute nodes or network switches
+// - Links (optional) describes the physical links to the connected vertices, keyed by vertex ID
+//
+// A vertex may be connected to more than one upper-tier switch when the provider
+// preserves all uplinks of a CLOS network; see ToTree.
 type Vertex struct {
 	Name     string
 	ID       string
 	Vertices map[string]*Vertex
+	Links    map[string]*Link
+}
+
+// Link describes the physical connection between two adjacent vertices
+type Link struct {
+	// Count is the number of parallel links
+	Count int
+	// Ports (optional) lists the connected ports of the parallel links
+	Ports []Port
+	// Width (optional) is the number of lanes per link, e.g. 4 for 4x
+	Width int
+	// Speed (optional) is the lane speed, e.g. HDR or NDR
+	Speed string
+	// Rate (optional) is the data rate of a single link in Gb/s
+	Rate float64
+}
+
+// Port is a pair of connected port numbers:
+// Local on the upper-tier switch, and Remote on the connected vertex
+type Port struct {
+	Local  int
+	Remote int
+}
+
+// Add aggregates parallel links into the link.
+// Width, speed and rate are kept only if the links agree on them.
+func (l *Link) Add(other *Link) {
+	if l.Count == 0 {
+		l.Width, l.Speed, l.Rate = other.Width, other.Speed, other.Rate
+	} else if l.Width != other.Width || l.Speed != other.Speed || l.Rate != other.Rate {
+		l.Width, l.Speed, l.Rate = 0, "", 0
+	}
+	l.Count += other.Count
+	l.Ports = append(l.Ports, other.Ports...)
+}
+
+// Bandwidth returns the aggregate data rate of the parallel links in Gb/s,
+// or 0 if the rate is unknown
+func (l *Link) Bandwidth() float64 {
+	return float64(l.Count) * l.Rate
 }
 
 func (v *Vertex) String() string {
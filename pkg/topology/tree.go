@@ -0,0 +1,168 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package topology
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// IsTree reports whether every vertex below the root is connected to a single parent
+func IsTree(root *Vertex) bool {
+	if root == nil {
+		return true
+	}
+
+	visited := make(map[string]bool)
+	queue := []*Vertex{root}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for id, w := range v.Vertices {
+			if visited[id] {
+				return false
+			}
+			visited[id] = true
+			queue = append(queue, w)
+		}
+	}
+	return true
+}
+
+// ToTree returns the graph with its switch hierarchy forced into a strict tree,
+// as required by tree-based schedulers. The graph is returned unchanged if it
+// already is a tree.
+func (g *Graph) ToTree() *Graph {
+	if g == nil || IsTree(g.Tiers) {
+		return g
+	}
+
+	tree := *g
+	tree.Tiers = ToTree(g.Tiers)
+	return &tree
+}
+
+// ToTree converts a multi-parent switch hierarchy (DAG) into a tree.
+// Like Merger, switches of the same tier connected to the same set of
+// lower-tier vertices are merged into one. A vertex still connected to several
+// switches afterwards remains only under the first of them in breadth-first
+// order. The input graph is not modified.
+func ToTree(root *Vertex) *Vertex {
+	if IsTree(root) {
+		return root
+	}
+
+	// group switches by their height above the compute nodes
+	heights := make(map[string]int)
+	tiers := make(map[int][]*Vertex)
+	var height func(*Vertex) int
+	height = func(v *Vertex) int {
+		if len(v.Vertices) == 0 {
+			return 0
+		}
+		if h, ok := heights[v.ID]; ok {
+			return h
+		}
+		h := 0
+		for _, w := range v.Vertices {
+			h = max(h, height(w)+1)
+		}
+		heights[v.ID] = h
+		tiers[h] = append(tiers[h], v)
+		return h
+	}
+	for _, w := range root.Vertices {
+		height(w)
+	}
+
+	// merge switches with identical children, starting from the lowest tier
+	refs := make(map[string]string)    // merged switch ID : remaining switch ID
+	merged := make(map[string]*Vertex) // remaining switch ID : new vertex
+	resolve := func(v *Vertex) *Vertex {
+		if ref, ok := refs[v.ID]; ok {
+			return merged[ref]
+		}
+		if w, ok := merged[v.ID]; ok {
+			return w
+		}
+		return v
+	}
+
+	for h := 1; h <= len(tiers); h++ {
+		layer := tiers[h]
+		slices.SortFunc(layer, func(a, b *Vertex) int { return strings.Compare(a.ID, b.ID) })
+		signatures := make(map[string]string)
+		for _, v := range layer {
+			children := make(map[string]*Vertex)
+			links := make(map[string]*Link)
+			for id, w := range v.Vertices {
+				child := resolve(w)
+				children[child.ID] = child
+				if link, ok := v.Links[id]; ok && link != nil {
+					if _, ok := links[child.ID]; !ok {
+						links[child.ID] = &Link{}
+					}
+					links[child.ID].Add(link)
+				}
+			}
+
+			signature := strings.Join(slices.Sorted(maps.Keys(children)), "\n")
+			if id, ok := signatures[signature]; ok {
+				refs[v.ID] = id
+				continue
+			}
+			signatures[signature] = v.ID
+
+			w := &Vertex{ID: v.ID, Name: v.Name, Vertices: children}
+			if len(links) != 0 {
+				w.Links = links
+			}
+			merged[v.ID] = w
+		}
+	}
+
+	tree := &Vertex{ID: root.ID, Name: root.Name, Vertices: make(map[string]*Vertex)}
+	for _, w := range root.Vertices {
+		child := resolve(w)
+		tree.Vertices[child.ID] = child
+	}
+
+	// keep every vertex under its first parent in breadth-first order
+	visited := make(map[string]bool)
+	queue := []*Vertex{tree}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, id := range slices.Sorted(maps.Keys(v.Vertices)) {
+			if visited[id] {
+				delete(v.Vertices, id)
+				delete(v.Links, id)
+				continue
+			}
+			visited[id] = true
+			queue = append(queue, v.Vertices[id])
+		}
+	}
+
+	// drop switches left without connected vertices
+	var prune func(*Vertex)
+	prune = func(v *Vertex) {
+		for id, w := range v.Vertices {
+			if heights[id] == 0 {
+				continue
+			}
+			prune(w)
+			if len(w.Vertices) == 0 {
+				delete(v.Vertices, id)
+				delete(v.Links, id)
+			}
+		}
+	}
+	prune(tree)
+
+	return tree
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package topology
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func getClosGraph() *Graph {
+	//
+	//     S1    S2    S3
+	//     | \  / |  / |
+	//     |  \/  | /  |
+	//     |  /\  |/   |
+	//     L1    L2    L3
+	//     |     |     |
+	//     n1    n2    n3
+	//
+	n1 := &Vertex{ID: "n1", Name: "node1"}
+	n2 := &Vertex{ID: "n2", Name: "node2"}
+	n3 := &Vertex{ID: "n3", Name: "node3"}
+	l1 := &Vertex{ID: "L1", Vertices: map[string]*Vertex{"n1": n1}}
+	l2 := &Vertex{ID: "L2", Vertices: map[string]*Vertex{"n2": n2}}
+	l3 := &Vertex{ID: "L3", Vertices: map[string]*Vertex{"n3": n3}}
+	s1 := &Vertex{
+		ID:       "S1",
+		Vertices: map[string]*Vertex{"L1": l1, "L2": l2},
+		Links:    map[string]*Link{"L1": {Count: 2}, "L2": {Count: 2}},
+	}
+	s2 := &Vertex{
+		ID:       "S2",
+		Vertices: map[string]*Vertex{"L1": l1, "L2": l2},
+		Links:    map[string]*Link{"L1": {Count: 2}, "L2": {Count: 2}},
+	}
+	s3 := &Vertex{
+		ID:       "S3",
+		Vertices: map[string]*Vertex{"L2": l2, "L3": l3},
+		Links:    map[string]*Link{"L2": {Count: 1}, "L3": {Count: 4}},
+	}
+	return &Graph{
+		Tiers: &Vertex{Vertices: map[string]*Vertex{"S1": s1, "S2": s2, "S3": s3}},
+	}
+}
+
+func TestIsTree(t *testing.T) {
+	require.True(t, IsTree(nil))
+	require.False(t, IsTree(getClosGraph().Tiers))
+
+	topo := NewClusterTopology()
+	for _, inst := range instances {
+		topo.Append(inst)
+	}
+	graph := topo.ToThreeTierGraph("test", []ComputeInstances{{Instances: i2n}}, 0, false)
+	require.True(t, IsTree(graph.Tiers))
+	require.Same(t, graph, graph.ToTree())
+}
+
+func TestToTree(t *testing.T) {
+	graph := getClosGraph()
+	tree := graph.ToTree()
+
+	n1 := &Vertex{ID: "n1", Name: "node1"}
+	n2 := &Vertex{ID: "n2", Name: "node2"}
+	n3 := &Vertex{ID: "n3", Name: "node3"}
+	expected := &Vertex{
+		Vertices: map[string]*Vertex{
+			"S1": {
+				ID: "S1",
+				Vertices: map[string]*Vertex{
+					"L1": {ID: "L1", Vertices: map[string]*Vertex{"n1": n1}},
+					"L2": {ID: "L2", Vertices: map[string]*Vertex{"n2": n2}},
+				},
+				Links: map[string]*Link{"L1": {Count: 2}, "L2": {Count: 2}},
+			},
+			"S3": {
+				ID:       "S3",
+				Vertices: map[string]*Vertex{"L3": {ID: "L3", Vertices: map[string]*Vertex{"n3": n3}}},
+				Links:    map[string]*Link{"L3": {Count: 4}},
+			},
+		},
+	}
+	require.Equal(t, expected, tree.Tiers)
+	require.True(t, IsTree(tree.Tiers))
+
+	// the input graph is not modified
+	require.Equal(t, getClosGraph(), graph)
+}
+
+func TestToTreeRailOptimized(t *testing.T) {
+	//
+	//        S1
+	//      /    \
+	//    L1      L2
+	//    | \    / |
+	//    |  \  /  |
+	//    |   \/   |
+	//    |   /\   |
+	//    n1     n2
+	//
+	n1 := &Vertex{ID: "n1", Name: "node1"}
+	n2 := &Vertex{ID: "n2", Name: "node2"}
+	l1 := &Vertex{ID: "L1", Vertices: map[string]*Vertex{"n1": n1, "n2": n2}}
+	l2 := &Vertex{ID: "L2", Vertices: map[string]*Vertex{"n1": n1, "n2": n2}}
+	s1 := &Vertex{ID: "S1", Vertices: map[string]*Vertex{"L1": l1, "L2": l2}}
+	root := &Vertex{Vertices: map[string]*Vertex{"S1": s1}}
+
+	expected := &Vertex{
+		Vertices: map[string]*Vertex{
+			"S1": {
+				ID: "S1",
+				Vertices: map[string]*Vertex{
+					"L1": {ID: "L1", Vertices: map[string]*Vertex{"n1": n1, "n2": n2}},
+				},
+			},
+		},
+	}
+	require.Equal(t, expected, ToTree(root))
+}
@@ -51,7 +51,10 @@ func getBlockSizes(blocks []*blockInfo, requestedBlockSizes []int) []int {
 }
 
 func (nt *NetworkTopology) toBlockTopology(wr io.Writer, skeletonOnly bool) *httperr.Error {
-	blocks := nt.complementBlocks(nt.blocks, nt.config.BlockSizes)
+	blocks, httpErr := nt.nameBlocks("", nt.complementBlocks(nt.blocks, nt.config.BlockSizes), "")
+	if httpErr != nil {
+		return httpErr
+	}
 	// Refresh nodeInfo.blockID so GetNodeTopologySpec returns IDs that match the
 	// emitted topology file. complementBlocks may renumber blocks when it splits
 	// a domain across multiple base blocks, invalidating the IDs set by initBlocks.
@@ -64,11 +67,20 @@ func (nt *NetworkTopology) toBlockTopology(wr io.Writer, skeletonOnly bool) *htt
 	}
 	blockSizes := getBlockSizes(blocks, nt.config.BlockSizes)
 
+	if nt.trail != nil {
+		names := make([]string, 0, len(blocks))
+		for _, bInfo := range blocks {
+			names = append(names, bInfo.id)
+		}
+		nt.explainBlocks("", blocks, names, blockSizes)
+	}
+
 	for _, bInfo := range blocks {
 		var comment string
-		if len(bInfo.name) != 0 {
+		if len(bInfo.name) != 0 && bInfo.name != bInfo.id {
 			comment = fmt.Sprintf("# %s=%s\n", bInfo.id, bInfo.name)
 		}
+		comment += nt.unusableNodesComment(bInfo)
 
 		var err error
 		if skeletonOnly || len(bInfo.nodes) == 0 {
@@ -93,3 +105,41 @@ func (nt *NetworkTopology) toBlockTopology(wr io.Writer, skeletonOnly bool) *htt
 
 	return nil
 }
+
+// nameBlocks returns the copies of the blocks of the topology renamed under the block naming
+// strategy and the ID map. By default, the blocks are named by defaultFormat with the 1-based
+// block position, or keep their IDs if defaultFormat is empty.
+func (nt *NetworkTopology) nameBlocks(topoName string, blocks []*blockInfo, defaultFormat string) ([]*blockInfo, *httperr.Error) {
+	domains := make([]string, 0, len(blocks))
+	for _, bInfo := range blocks {
+		domains = append(domains, bInfo.name)
+	}
+	names, err := nt.config.Naming.BlockNames(domains)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+	if names == nil {
+		if len(defaultFormat) == 0 && nt.config.IDMap == nil {
+			return blocks, nil
+		}
+		names = make([]string, 0, len(blocks))
+		for i, bInfo := range blocks {
+			if len(defaultFormat) == 0 {
+				names = append(names, bInfo.id)
+			} else {
+				names = append(names, fmt.Sprintf(defaultFormat, i+1))
+			}
+		}
+	}
+	if nt.config.IDMap != nil {
+		names = nt.stableBlockNames(topoName, blocks, names)
+	}
+
+	named := make([]*blockInfo, 0, len(blocks))
+	for i, bInfo := range blocks {
+		copied := *bInfo
+		copied.id = names[i]
+		named = append(named, &copied)
+	}
+	return named, nil
+}
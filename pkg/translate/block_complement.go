@@ -68,9 +68,16 @@ func (nt *NetworkTopology) complementBlocks(blocks []*blockInfo, blockSizes []in
 	}
 	allSlots := collectBaseBlockSlots(actualTree)
 
+	var placements map[*baseBlockNode]*basePlacement
+	if nt.trail != nil {
+		placements = getBasePlacements(actualTree)
+	}
+
 	out := make([]*blockInfo, 0, len(allSlots))
 	for i, bb := range allSlots {
-		out = append(out, baseBlockToBlockInfo(bb, byName, i+1))
+		bInfo := baseBlockToBlockInfo(bb, byName, i+1)
+		bInfo.placement = placements[bb]
+		out = append(out, bInfo)
 	}
 	return out
 }
@@ -0,0 +1,212 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/cluset"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// BlockPlan evaluates candidate block sizes against the accelerator domains
+type BlockPlan struct {
+	Candidates []*BlockSizesCandidate `json:"candidates"`
+	// Recommended is the block sizes of the candidate with the least wasted and padded node slots
+	Recommended []int `json:"recommended"`
+}
+
+// BlockSizesCandidate describes the block tree built for candidate block sizes
+type BlockSizesCandidate struct {
+	BlockSizes []int `json:"blockSizes"`
+	// Requested marks the block sizes set in the configuration
+	Requested bool `json:"requested,omitempty"`
+	// Nodes is the number of nodes placed in the base blocks
+	Nodes int `json:"nodes"`
+	// Slots is the number of node slots of all base blocks
+	Slots int `json:"slots"`
+	// WastedSlots is the number of empty slots in partially filled base blocks
+	WastedSlots int `json:"wastedSlots"`
+	// PaddedSlots is the number of slots in the empty base blocks added to align the block tree
+	PaddedSlots int `json:"paddedSlots"`
+	// Levels describes the blocks of each block size
+	Levels []*BlockLevel `json:"levels"`
+}
+
+// BlockLevel describes the blocks of a block size.
+// A block of a level consists of consecutive base blocks.
+type BlockLevel struct {
+	BlockSize int `json:"blockSize"`
+	Blocks    int `json:"blocks"`
+	// FullBlocks is the number of blocks without empty slots
+	FullBlocks int `json:"fullBlocks"`
+	// LargestJob is the largest number of nodes a job can get within a single block of the level
+	LargestJob int `json:"largestJob"`
+}
+
+// PlanBlockSizes evaluates the candidate block sizes against the accelerator domains.
+// The base block size candidates are the domain sizes and the divisors of the largest domain size;
+// each is doubled as the default block sizes are, up to the number of base blocks.
+// The requested block sizes, if any, are evaluated as well.
+// The recommended candidate has the least wasted and padded slots and, on a tie,
+// the largest base block size, which keeps most of a domain within a base block.
+func PlanBlockSizes(domains topology.DomainMap, requested []int) *BlockPlan {
+	plan := &BlockPlan{Candidates: []*BlockSizesCandidate{}}
+
+	sizes := make(map[int]bool)
+	maxSize := 0
+	for _, hosts := range domains {
+		if n := len(hosts); n != 0 {
+			sizes[n] = true
+			maxSize = max(maxSize, n)
+		}
+	}
+	for d := 1; d <= maxSize; d++ {
+		if maxSize%d == 0 {
+			sizes[d] = true
+		}
+	}
+	if len(sizes) == 0 {
+		return plan
+	}
+
+	for _, base := range slices.Sorted(maps.Keys(sizes)) {
+		// the number of base blocks without tree padding defines the levels, as in getBlockSizes
+		blocks := len(collectBaseBlockSlots(buildBlockTree(domains, []int{base})))
+		blockSizes := []int{base}
+		for bs := 2 * base; bs <= blocks*base; bs *= 2 {
+			blockSizes = append(blockSizes, bs)
+		}
+		plan.Candidates = append(plan.Candidates, evaluateBlockSizes(domains, blockSizes))
+	}
+
+	if len(requested) != 0 {
+		if i := slices.IndexFunc(plan.Candidates, func(c *BlockSizesCandidate) bool {
+			return slices.Equal(c.BlockSizes, requested)
+		}); i >= 0 {
+			plan.Candidates[i].Requested = true
+		} else {
+			candidate := evaluateBlockSizes(domains, requested)
+			candidate.Requested = true
+			plan.Candidates = append(plan.Candidates, candidate)
+		}
+	}
+
+	best := slices.MinFunc(plan.Candidates, func(a, b *BlockSizesCandidate) int {
+		return cmp.Or(
+			cmp.Compare(a.WastedSlots+a.PaddedSlots, b.WastedSlots+b.PaddedSlots),
+			cmp.Compare(b.BlockSizes[0], a.BlockSizes[0]),
+			cmp.Compare(len(b.BlockSizes), len(a.BlockSizes)),
+		)
+	})
+	plan.Recommended = best.BlockSizes
+
+	return plan
+}
+
+// evaluateBlockSizes packs the domains into the block tree shaped by blockSizes
+// and counts the used, wasted and padded slots of its base blocks
+func evaluateBlockSizes(domains topology.DomainMap, blockSizes []int) *BlockSizesCandidate {
+	base := blockSizes[0]
+	candidate := &BlockSizesCandidate{
+		BlockSizes: blockSizes,
+		Levels:     make([]*BlockLevel, 0, len(blockSizes)),
+	}
+
+	slots := collectBaseBlockSlots(buildBlockTree(domains, blockSizes))
+	for _, bb := range slots {
+		candidate.Nodes += bb.nodeCount
+		candidate.Slots += base
+		if bb.nodeCount == 0 {
+			candidate.PaddedSlots += base
+		} else {
+			candidate.WastedSlots += base - bb.nodeCount
+		}
+	}
+
+	for _, bs := range blockSizes {
+		level := &BlockLevel{BlockSize: bs}
+		size := max(bs/base, 1)
+		for start := 0; start+size <= len(slots); start += size {
+			nodes := 0
+			for _, bb := range slots[start : start+size] {
+				nodes += bb.nodeCount
+			}
+			level.Blocks++
+			if nodes == bs {
+				level.FullBlocks++
+			}
+			level.LargestJob = max(level.LargestJob, nodes)
+		}
+		candidate.Levels = append(candidate.Levels, level)
+	}
+
+	return candidate
+}
+
+// withPlannedBlockSizes returns the config with the block sizes recommended by PlanBlockSizes
+// for the cluster-wide and per-partition topologies requesting them
+func (cfg *Config) withPlannedBlockSizes(domains topology.DomainMap) *Config {
+	if len(domains) == 0 {
+		return cfg
+	}
+
+	planned := *cfg
+	if cfg.AutoBlockSizes && len(cfg.BlockSizes) == 0 {
+		planned.BlockSizes = PlanBlockSizes(domains, nil).Recommended
+		klog.InfoS("Planned block sizes", "blockSizes", planned.BlockSizes)
+	}
+
+	if len(cfg.Topologies) != 0 {
+		planned.Topologies = make(map[string]*TopologySpec, len(cfg.Topologies))
+		for name, spec := range cfg.Topologies {
+			if spec.AutoBlockSizes && len(spec.BlockSizes) == 0 && spec.Plugin == topology.TopologyBlock {
+				selected := newSelector(cluset.Expand(spec.Nodes))
+				partDomains := filterDomains(domains, func(_, host string) bool { return selected[host] })
+				copied := *spec
+				copied.BlockSizes = PlanBlockSizes(partDomains, nil).Recommended
+				klog.InfoS("Planned block sizes", "topology", name, "blockSizes", copied.BlockSizes)
+				spec = &copied
+			}
+			planned.Topologies[name] = spec
+		}
+	}
+
+	return &planned
+}
+
+// withDefaultBlockSizes returns the config with the default block sizes of getBlockSizes
+// derived from the domains, for the cluster-wide and per-partition block topologies without block sizes
+func (cfg *Config) withDefaultBlockSizes(domains topology.DomainMap) *Config {
+	defaulted := *cfg
+	if len(cfg.Topologies) == 0 {
+		if cfg.Plugin == topology.TopologyBlock && len(cfg.BlockSizes) == 0 {
+			if blocks := toBlockInfos(domains); len(blocks) != 0 {
+				defaulted.BlockSizes = getBlockSizes(blocks, nil)
+			}
+		}
+		return &defaulted
+	}
+
+	defaulted.Topologies = make(map[string]*TopologySpec, len(cfg.Topologies))
+	for name, spec := range cfg.Topologies {
+		if spec.Plugin == topology.TopologyBlock && len(spec.BlockSizes) == 0 {
+			selected := newSelector(cluset.Expand(spec.Nodes))
+			partDomains := filterDomains(domains, func(_, host string) bool { return selected[host] })
+			if blocks := toBlockInfos(partDomains); len(blocks) != 0 {
+				copied := *spec
+				copied.BlockSizes = getBlockSizes(blocks, nil)
+				spec = &copied
+			}
+		}
+		defaulted.Topologies[name] = spec
+	}
+	return &defaulted
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestPlanBlockSizes(t *testing.T) {
+	graph, _ := getBlockWithDiffNumNodeTestSet()
+
+	plan := PlanBlockSizes(graph.Domains, []int{2, 4})
+	require.Equal(t, &BlockPlan{
+		Candidates: []*BlockSizesCandidate{
+			{
+				BlockSizes:  []int{1, 2, 4},
+				Nodes:       7,
+				Slots:       8,
+				PaddedSlots: 1,
+				Levels: []*BlockLevel{
+					{BlockSize: 1, Blocks: 8, FullBlocks: 7, LargestJob: 1},
+					{BlockSize: 2, Blocks: 4, FullBlocks: 3, LargestJob: 2},
+					{BlockSize: 4, Blocks: 2, FullBlocks: 1, LargestJob: 4},
+				},
+			},
+			{
+				BlockSizes:  []int{2, 4, 8},
+				Nodes:       7,
+				Slots:       8,
+				WastedSlots: 1,
+				Levels: []*BlockLevel{
+					{BlockSize: 2, Blocks: 4, FullBlocks: 3, LargestJob: 2},
+					{BlockSize: 4, Blocks: 2, FullBlocks: 1, LargestJob: 4},
+					{BlockSize: 8, Blocks: 1, FullBlocks: 0, LargestJob: 7},
+				},
+			},
+			{
+				BlockSizes:  []int{3, 6},
+				Nodes:       7,
+				Slots:       12,
+				WastedSlots: 2,
+				PaddedSlots: 3,
+				Levels: []*BlockLevel{
+					{BlockSize: 3, Blocks: 4, FullBlocks: 2, LargestJob: 3},
+					{BlockSize: 6, Blocks: 2, FullBlocks: 0, LargestJob: 4},
+				},
+			},
+			{
+				BlockSizes:  []int{4, 8},
+				Nodes:       7,
+				Slots:       8,
+				WastedSlots: 1,
+				Levels: []*BlockLevel{
+					{BlockSize: 4, Blocks: 2, FullBlocks: 1, LargestJob: 4},
+					{BlockSize: 8, Blocks: 1, FullBlocks: 0, LargestJob: 7},
+				},
+			},
+			{
+				BlockSizes:  []int{2, 4},
+				Requested:   true,
+				Nodes:       7,
+				Slots:       8,
+				WastedSlots: 1,
+				Levels: []*BlockLevel{
+					{BlockSize: 2, Blocks: 4, FullBlocks: 3, LargestJob: 2},
+					{BlockSize: 4, Blocks: 2, FullBlocks: 1, LargestJob: 4},
+				},
+			},
+		},
+		Recommended: []int{4, 8},
+	}, plan)
+
+	// requested block sizes matching a candidate are marked
+	plan = PlanBlockSizes(graph.Domains, []int{3, 6})
+	require.Len(t, plan.Candidates, 4)
+	require.True(t, plan.Candidates[2].Requested)
+
+	require.Equal(t, &BlockPlan{Candidates: []*BlockSizesCandidate{}}, PlanBlockSizes(nil, nil))
+}
+
+func TestAutoBlockSizes(t *testing.T) {
+	graph, _ := getBlockWithDiffNumNodeTestSet()
+
+	cfg := &Config{Plugin: topology.TopologyBlock, AutoBlockSizes: true}
+	nt, err := NewNetworkTopology(graph, cfg)
+	require.NoError(t, err)
+	require.Empty(t, cfg.BlockSizes)
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, nt.Generate(buf))
+	require.Equal(t, `# block001=B1
+BlockName=block001 Nodes=Node[104-106]
+# block002=B2
+BlockName=block002 Nodes=Node[201-202,205-206]
+BlockSizes=4,8
+`, buf.String())
+
+	// requested block sizes take precedence
+	nt, err = NewNetworkTopology(graph, &Config{Plugin: topology.TopologyBlock, BlockSizes: []int{3}, AutoBlockSizes: true})
+	require.NoError(t, err)
+	require.Equal(t, []int{3}, nt.config.BlockSizes)
+
+	// per-partition topologies are planned for their nodes
+	cfg = &Config{
+		Topologies: map[string]*TopologySpec{
+			"topo1": {
+				Plugin:         topology.TopologyBlock,
+				AutoBlockSizes: true,
+				Nodes:          []string{"Node[104-106]", "Node[201-202]"},
+			},
+			"topo2": {
+				Plugin:         topology.TopologyBlock,
+				AutoBlockSizes: true,
+				Nodes:          []string{"Node[201-202,205-206]"},
+			},
+		},
+	}
+	nt, err = NewNetworkTopology(graph, cfg)
+	require.NoError(t, err)
+	require.Equal(t, []int{3, 6}, nt.config.Topologies["topo1"].BlockSizes)
+	require.Equal(t, []int{4}, nt.config.Topologies["topo2"].BlockSizes)
+	require.Empty(t, cfg.Topologies["topo1"].BlockSizes)
+}
@@ -122,6 +122,40 @@ BlockSizes=1,2
 	}
 }
 
+func TestBlockTopologyNaming(t *testing.T) {
+	v, _ := getBlockWithDiffNumNodeTestSet()
+	cfg := &Config{
+		Plugin:     topology.TopologyBlock,
+		BlockSizes: []int{2, 4, 8},
+		Naming:     topology.Naming{Blocks: topology.NamingID},
+	}
+	nt, err := NewNetworkTopology(v, cfg)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, nt.Generate(buf))
+	require.Equal(t, `BlockName=B1 Nodes=Node[104-105]
+# B1-2=B1
+BlockName=B1-2 Nodes=Node106
+BlockName=B2 Nodes=Node[201-202]
+# B2-2=B2
+BlockName=B2-2 Nodes=Node[205-206]
+BlockSizes=2,4,8
+`, buf.String())
+
+	spec, httpErr := nt.GetNodeTopologySpec("Node205", nil)
+	require.Nil(t, httpErr)
+	require.Equal(t, "default:B2-2", spec)
+
+	// a template without a unique placeholder per block
+	cfg.Naming = topology.Naming{Blocks: "{name}"}
+	nt, err = NewNetworkTopology(v, cfg)
+	require.NoError(t, err)
+	httpErr = nt.Generate(&bytes.Buffer{})
+	require.NotNil(t, httpErr)
+	require.Equal(t, `blocks 1 and 2 have the same name "B1"`, httpErr.Error())
+}
+
 func TestGetBlockSizes(t *testing.T) {
 	testCases := []struct {
 		name           string
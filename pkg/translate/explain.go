@@ -0,0 +1,361 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// NodeExplanation is the decision trail that placed a node in the switches and blocks of the topology
+type NodeExplanation struct {
+	Node       string `json:"node"`
+	InstanceID string `json:"instanceID,omitempty"`
+	// Domain is the accelerator domain reported by the provider
+	Domain string `json:"domain,omitempty"`
+	// DomainOrder is the position of the domain among the blocks before complementing
+	DomainOrder int `json:"domainOrder,omitempty"`
+	// NoTopology is set if the provider reported no network topology for the node
+	NoTopology bool `json:"noTopology,omitempty"`
+	// Switches lists the switches above the node, starting from the leaf switch
+	Switches []*ExplainedSwitch `json:"switches,omitempty"`
+	// Blocks lists the blocks of the node in the cluster-wide or per-partition block topologies
+	Blocks []*ExplainedBlock `json:"blocks,omitempty"`
+	// Trail describes the decisions in the order they were made
+	Trail []string `json:"trail"`
+}
+
+// ExplainedSwitch describes a switch above the node
+type ExplainedSwitch struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	// Order is the position of the switch in the breadth-first traversal of the tree
+	Order int `json:"order"`
+	// Child is the vertex below the switch on the path to the node
+	Child string `json:"child"`
+	// Links, Ports and Speed describe the provider links between the switch and the child
+	Links int      `json:"links,omitempty"`
+	Ports []string `json:"ports,omitempty"`
+	Speed string   `json:"speed,omitempty"`
+	// Parents lists all the switches the child was connected to
+	// before the switch hierarchy was merged into a tree
+	Parents []string `json:"parents,omitempty"`
+}
+
+// ExplainedBlock describes the block of the node in a block topology
+type ExplainedBlock struct {
+	Topology string `json:"topology,omitempty"`
+	Block    string `json:"block"`
+	// Position is the position of the block in the topology config
+	Position int `json:"position"`
+	// Group is the position of the base block group of the domain in the block tree,
+	// GroupBlock the position of the base block within the group,
+	// and PaddingBlocks the number of empty base blocks padding the group
+	Group         int `json:"group,omitempty"`
+	GroupBlock    int `json:"groupBlock,omitempty"`
+	PaddingBlocks int `json:"paddingBlocks,omitempty"`
+	// Levels describes the blocks of the node for each block size above the base block size
+	Levels []*ExplainedLevel `json:"levels"`
+}
+
+// ExplainedLevel describes the block of the node for a block size
+type ExplainedLevel struct {
+	BlockSize int `json:"blockSize"`
+	// Block is the position of the block among the blocks of the block size
+	Block int `json:"block"`
+	// Domains lists the accelerator domains sharing the block
+	Domains []string `json:"domains"`
+}
+
+// basePlacement records the position of a complemented block in the block tree
+type basePlacement struct {
+	group      int
+	groupBlock int
+	groupSize  int
+	padding    int
+}
+
+func (e *NodeExplanation) addf(format string, args ...any) {
+	e.Trail = append(e.Trail, fmt.Sprintf(format, args...))
+}
+
+// Explain returns the decision trail of the node placement. The topology must be created
+// with Config.Explain set, and generated first to record the block placement.
+func (nt *NetworkTopology) Explain(node string) (*NodeExplanation, *httperr.Error) {
+	if nt.trail == nil {
+		return nil, httperr.NewError(http.StatusInternalServerError, "explain mode is not enabled")
+	}
+
+	exp, ok := nt.trail[node]
+	if !ok {
+		return nil, httperr.NewError(http.StatusNotFound, fmt.Sprintf("node %q not found in topology", node))
+	}
+
+	return exp, nil
+}
+
+// explained returns the decision trail of the node, if the explain mode is enabled
+func (nt *NetworkTopology) explained(node string) *NodeExplanation {
+	if nt.trail == nil {
+		return nil
+	}
+	exp, ok := nt.trail[node]
+	if !ok {
+		exp = &NodeExplanation{Node: node, Trail: []string{}}
+		nt.trail[node] = exp
+	}
+	return exp
+}
+
+// getParents returns the vertices connected to several switches with the sorted IDs of these switches
+func getParents(root *topology.Vertex) map[string][]string {
+	if root == nil {
+		return nil
+	}
+
+	parents := make(map[string][]string)
+	visited := make(map[string]bool)
+	queue := []*topology.Vertex{root}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range v.Vertices {
+			if len(v.ID) != 0 && !slices.Contains(parents[w.ID], v.ID) {
+				parents[w.ID] = append(parents[w.ID], v.ID)
+			}
+			if !visited[w.ID] {
+				visited[w.ID] = true
+				queue = append(queue, w)
+			}
+		}
+	}
+
+	for id, ids := range parents {
+		if len(ids) < 2 {
+			delete(parents, id)
+		} else {
+			slices.Sort(ids)
+		}
+	}
+	return parents
+}
+
+// explainTree records the switches above the nodes, following the breadth-first traversal
+// of the tree with sorted vertex IDs, as the blocks are ordered
+func (nt *NetworkTopology) explainTree(root *topology.Vertex, parents map[string][]string) {
+	if nt.trail == nil || root == nil {
+		return
+	}
+
+	order := make(map[string]int)
+	parentOf := make(map[string]*topology.Vertex)
+	queue := []*topology.Vertex{root}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+
+		if len(v.Vertices) == 0 {
+			nt.explainSwitches(v, parentOf, order, parents)
+			continue
+		}
+
+		if len(v.ID) != 0 {
+			order[v.ID] = len(order) + 1
+		}
+		for _, key := range slices.Sorted(maps.Keys(v.Vertices)) {
+			w := v.Vertices[key]
+			if _, ok := parentOf[w.ID]; !ok {
+				parentOf[w.ID] = v
+				queue = append(queue, w)
+			}
+		}
+	}
+}
+
+func (nt *NetworkTopology) explainSwitches(node *topology.Vertex, parentOf map[string]*topology.Vertex, order map[string]int, parents map[string][]string) {
+	exp := nt.explained(node.Name)
+	exp.InstanceID = node.ID
+
+	child := node
+	for sw := parentOf[node.ID]; sw != nil && len(sw.ID) != 0; sw = parentOf[sw.ID] {
+		if sw.ID == topology.NoTopology {
+			exp.NoTopology = true
+			exp.addf("provider reported no network topology for instance %q: placed under the %q switch", node.ID, topology.NoTopology)
+			return
+		}
+
+		es := &ExplainedSwitch{
+			ID:      sw.ID,
+			Order:   order[sw.ID],
+			Child:   child.ID,
+			Parents: parents[child.ID],
+		}
+		if sw.Name != sw.ID {
+			es.Name = sw.Name
+		}
+		if link := sw.Links[child.ID]; link != nil {
+			es.Links = link.Count
+			es.Speed = link.Speed
+			for _, port := range link.Ports {
+				es.Ports = append(es.Ports, fmt.Sprintf("%d:%d", port.Local, port.Remote))
+			}
+		}
+		exp.Switches = append(exp.Switches, es)
+
+		if len(es.Parents) != 0 {
+			exp.addf("%q is connected to switches %v: kept under %q when the switch hierarchy was merged into a tree",
+				child.ID, es.Parents, sw.ID)
+		}
+		if es.Links != 0 {
+			exp.addf("switch %q (breadth-first order %d) connects %q by %d link(s)", sw.ID, es.Order, child.ID, es.Links)
+		} else {
+			exp.addf("switch %q (breadth-first order %d) connects %q", sw.ID, es.Order, child.ID)
+		}
+		child = sw
+	}
+}
+
+// explainDomains records the accelerator domains of the nodes and the handling of the unusable nodes
+func (nt *NetworkTopology) explainDomains(domains topology.DomainMap) {
+	if nt.trail == nil {
+		return
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(domains)) {
+		for _, host := range slices.Sorted(maps.Keys(domains[name])) {
+			exp := nt.explained(host)
+			exp.Domain = name
+			if hostInfo := domains[name][host]; hostInfo != nil && len(exp.InstanceID) == 0 {
+				exp.InstanceID = hostInfo.InstanceID
+			}
+			exp.addf("provider reported accelerator domain %q", name)
+
+			if state, ok := nt.config.UnusableNodes[host]; ok {
+				if nt.config.ExcludeUnusableNodes {
+					exp.addf("excluded from the blocks in state %s", state)
+				} else {
+					exp.addf("kept in the blocks and annotated as unusable in state %s", state)
+				}
+			}
+		}
+	}
+}
+
+// explainBlockOrder records the order of the domain blocks before complementing
+func (nt *NetworkTopology) explainBlockOrder(tree bool) {
+	if nt.trail == nil {
+		return
+	}
+
+	for i, block := range nt.blocks {
+		for _, node := range block.nodes {
+			exp := nt.explained(node)
+			exp.DomainOrder = i + 1
+			if tree {
+				exp.addf("domain %q is block %d of %d, ordered by the first node of each domain in the breadth-first traversal of the tree",
+					block.name, i+1, len(nt.blocks))
+			} else {
+				exp.addf("domain %q is block %d of %d, ordered by domain name", block.name, i+1, len(nt.blocks))
+			}
+		}
+	}
+}
+
+// collectBaseBlockGroups returns the groups of sibling base blocks in the tree via a left-to-right DFS.
+// The base blocks of a domain, together with their padding blocks, form a group.
+func collectBaseBlockGroups(tree *aggregateBlockNode) [][]*baseBlockNode {
+	var groups [][]*baseBlockNode
+	var walk func(*aggregateBlockNode)
+	walk = func(n *aggregateBlockNode) {
+		group := []*baseBlockNode{}
+		for _, child := range n.children {
+			switch c := child.(type) {
+			case *baseBlockNode:
+				group = append(group, c)
+			case *aggregateBlockNode:
+				walk(c)
+			}
+		}
+		if len(group) != 0 {
+			groups = append(groups, group)
+		}
+	}
+	walk(tree)
+	return groups
+}
+
+// getBasePlacements returns the position of each base block of the tree in its group
+func getBasePlacements(tree *aggregateBlockNode) map[*baseBlockNode]*basePlacement {
+	placements := make(map[*baseBlockNode]*basePlacement)
+	for i, group := range collectBaseBlockGroups(tree) {
+		padding := 0
+		for _, bb := range group {
+			if bb.nodeCount == 0 {
+				padding++
+			}
+		}
+		for j, bb := range group {
+			placements[bb] = &basePlacement{group: i + 1, groupBlock: j + 1, groupSize: len(group), padding: padding}
+		}
+	}
+	return placements
+}
+
+// explainBlocks records the blocks of the nodes in the cluster-wide or per-partition block topology
+func (nt *NetworkTopology) explainBlocks(topoName string, blocks []*blockInfo, names []string, blockSizes []int) {
+	if nt.trail == nil || len(blocks) == 0 || len(blockSizes) == 0 {
+		return
+	}
+
+	prefix := ""
+	if len(topoName) != 0 {
+		prefix = fmt.Sprintf("topology %q: ", topoName)
+	}
+
+	base := blockSizes[0]
+	for i, block := range blocks {
+		for _, node := range block.nodes {
+			exp, ok := nt.trail[node]
+			if !ok {
+				continue
+			}
+
+			eb := &ExplainedBlock{Topology: topoName, Block: names[i], Position: i + 1}
+			if p := block.placement; p != nil {
+				eb.Group, eb.GroupBlock, eb.PaddingBlocks = p.group, p.groupBlock, p.padding
+				exp.addf("%sdomain %q fills %d base block(s) of size %d in group %d; the node is in base block %d of the group",
+					prefix, block.name, p.groupSize-p.padding, base, p.group, p.groupBlock)
+				if p.padding != 0 {
+					exp.addf("%sgroup %d is padded with %d empty base block(s) to align the block tree", prefix, p.group, p.padding)
+				}
+			}
+			exp.addf("%splaced in %s, block %d of %d", prefix, names[i], i+1, len(blocks))
+
+			for _, bs := range blockSizes[1:] {
+				size := max(bs/base, 1)
+				start := i / size * size
+				domains := []string{}
+				for _, b := range blocks[start:min(start+size, len(blocks))] {
+					if len(b.name) != 0 && !slices.Contains(domains, b.name) {
+						domains = append(domains, b.name)
+					}
+				}
+				slices.Sort(domains)
+				eb.Levels = append(eb.Levels, &ExplainedLevel{BlockSize: bs, Block: i/size + 1, Domains: domains})
+				exp.addf("%sblock size %d: in block %d of %d base blocks with domains %v", prefix, bs, i/size+1, size, domains)
+			}
+			if eb.Levels == nil {
+				eb.Levels = []*ExplainedLevel{}
+			}
+			exp.Blocks = append(exp.Blocks, eb)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestExplain(t *testing.T) {
+	graph, _ := getBlockWithDiffNumNodeTestSet()
+	graph.Tiers.Vertices[topology.NoTopology] = &topology.Vertex{
+		ID:       topology.NoTopology,
+		Vertices: map[string]*topology.Vertex{"I31": {ID: "I31", Name: "Node301"}},
+	}
+
+	cfg := &Config{
+		Plugin:        topology.TopologyBlock,
+		BlockSizes:    []int{2, 4},
+		UnusableNodes: map[string]string{"Node106": "DOWN"},
+		Explain:       true,
+	}
+	nt, err := NewNetworkTopology(graph, cfg)
+	require.NoError(t, err)
+	require.Nil(t, nt.Generate(io.Discard))
+
+	exp, httpErr := nt.Explain("Node106")
+	require.Nil(t, httpErr)
+	require.Equal(t, &NodeExplanation{
+		Node:        "Node106",
+		InstanceID:  "I16",
+		Domain:      "B1",
+		DomainOrder: 1,
+		Switches: []*ExplainedSwitch{
+			{ID: "S2", Order: 3, Child: "I16"},
+			{ID: "S1", Order: 1, Child: "S2"},
+		},
+		Blocks: []*ExplainedBlock{
+			{
+				Block:      "block002",
+				Position:   2,
+				Group:      1,
+				GroupBlock: 2,
+				Levels: []*ExplainedLevel{
+					{BlockSize: 4, Block: 1, Domains: []string{"B1"}},
+				},
+			},
+		},
+		Trail: []string{
+			`switch "S2" (breadth-first order 3) connects "I16"`,
+			`switch "S1" (breadth-first order 1) connects "S2"`,
+			`provider reported accelerator domain "B1"`,
+			`kept in the blocks and annotated as unusable in state DOWN`,
+			`domain "B1" is block 1 of 2, ordered by the first node of each domain in the breadth-first traversal of the tree`,
+			`domain "B1" fills 2 base block(s) of size 2 in group 1; the node is in base block 2 of the group`,
+			`placed in block002, block 2 of 4`,
+			`block size 4: in block 1 of 2 base blocks with domains [B1]`,
+		},
+	}, exp)
+
+	exp, httpErr = nt.Explain("Node301")
+	require.Nil(t, httpErr)
+	require.Equal(t, &NodeExplanation{
+		Node:       "Node301",
+		InstanceID: "I31",
+		NoTopology: true,
+		Trail: []string{
+			`provider reported no network topology for instance "I31": placed under the "no-topology" switch`,
+		},
+	}, exp)
+
+	_, httpErr = nt.Explain("Node999")
+	require.NotNil(t, httpErr)
+	require.Equal(t, http.StatusNotFound, httpErr.Code())
+	require.Equal(t, `node "Node999" not found in topology`, httpErr.Error())
+
+	nt, err = NewNetworkTopology(graph, &Config{Plugin: topology.TopologyBlock})
+	require.NoError(t, err)
+	_, httpErr = nt.Explain("Node106")
+	require.NotNil(t, httpErr)
+	require.Equal(t, "explain mode is not enabled", httpErr.Error())
+}
+
+func TestExplainPadding(t *testing.T) {
+	graph, _ := getBlockWithDiffNumNodeTestSet()
+
+	cfg := &Config{
+		Topologies: map[string]*TopologySpec{
+			"topo1": {
+				Plugin:     topology.TopologyBlock,
+				BlockSizes: []int{2, 4, 8},
+				Nodes:      []string{"Node[104-106]", "Node[201-202]"},
+			},
+		},
+		Explain: true,
+	}
+	nt, err := NewNetworkTopology(graph, cfg)
+	require.NoError(t, err)
+	require.Nil(t, nt.Generate(io.Discard))
+
+	exp, httpErr := nt.Explain("Node201")
+	require.Nil(t, httpErr)
+	require.Equal(t, []*ExplainedBlock{
+		{
+			Topology:      "topo1",
+			Block:         "block3",
+			Position:      3,
+			Group:         2,
+			GroupBlock:    1,
+			PaddingBlocks: 1,
+			Levels: []*ExplainedLevel{
+				{BlockSize: 4, Block: 2, Domains: []string{"B2"}},
+				{BlockSize: 8, Block: 1, Domains: []string{"B1", "B2"}},
+			},
+		},
+	}, exp.Blocks)
+	require.Contains(t, exp.Trail, `topology "topo1": group 2 is padded with 1 empty base block(s) to align the block tree`)
+}
+
+func TestExplainParents(t *testing.T) {
+	//
+	//    S1   S2
+	//     \   /
+	//      L1
+	//      |
+	//      n1
+	//
+	n1 := &topology.Vertex{ID: "n1", Name: "node1"}
+	l1 := &topology.Vertex{
+		ID:       "L1",
+		Vertices: map[string]*topology.Vertex{"n1": n1},
+		Links:    map[string]*topology.Link{"n1": {Count: 1, Ports: []topology.Port{{Local: 7, Remote: 1}}, Speed: "NDR"}},
+	}
+	s1 := &topology.Vertex{
+		ID:       "S1",
+		Name:     "spine1",
+		Vertices: map[string]*topology.Vertex{"L1": l1},
+		Links:    map[string]*topology.Link{"L1": {Count: 2}},
+	}
+	s2 := &topology.Vertex{
+		ID:       "S2",
+		Vertices: map[string]*topology.Vertex{"L1": l1},
+		Links:    map[string]*topology.Link{"L1": {Count: 2}},
+	}
+	graph := &topology.Graph{
+		Tiers: &topology.Vertex{Vertices: map[string]*topology.Vertex{"S1": s1, "S2": s2}},
+	}
+
+	nt, err := NewNetworkTopology(graph, &Config{Plugin: topology.TopologyTree, Explain: true})
+	require.NoError(t, err)
+	require.Nil(t, nt.Generate(io.Discard))
+
+	exp, httpErr := nt.Explain("node1")
+	require.Nil(t, httpErr)
+	require.Equal(t, &NodeExplanation{
+		Node:       "node1",
+		InstanceID: "n1",
+		Switches: []*ExplainedSwitch{
+			{ID: "L1", Order: 2, Child: "n1", Links: 1, Ports: []string{"7:1"}, Speed: "NDR"},
+			{ID: "S1", Name: "spine1", Order: 1, Child: "L1", Links: 2, Parents: []string{"S1", "S2"}},
+		},
+		Trail: []string{
+			`switch "L1" (breadth-first order 2) connects "n1" by 1 link(s)`,
+			`"L1" is connected to switches [S1 S2]: kept under "S1" when the switch hierarchy was merged into a tree`,
+			`switch "S1" (breadth-first order 1) connects "L1" by 2 link(s)`,
+		},
+	}, exp)
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+var numberedNameRegexp = regexp.MustCompile(`^(.*?)(\d+)$`)
+
+// IDMap records the switch and block names allocated by the previous topology generations,
+// so that the existing switches and accelerator domains keep their names across regenerations
+// and only the new ones get fresh names. The names of the removed switches and domains stay
+// reserved, so they are not given to other switches or domains.
+type IDMap struct {
+	// Switches maps the switch IDs to the switch names
+	Switches map[string]string `json:"switches,omitempty"`
+	// Blocks maps the topology names, empty for the cluster-wide topology, to the block names
+	// keyed by the accelerator domain, with a "-<n>" suffix for the n-th block of a domain
+	// split across several base blocks, or by "#padding<n>" for the n-th padding block
+	Blocks map[string]map[string]string `json:"blocks,omitempty"`
+}
+
+func NewIDMap() *IDMap {
+	return &IDMap{
+		Switches: make(map[string]string),
+		Blocks:   make(map[string]map[string]string),
+	}
+}
+
+// ParseIDMap returns the ID map serialized by Marshal, or an empty map for empty data
+func ParseIDMap(data []byte) (*IDMap, error) {
+	m := NewIDMap()
+	if len(data) == 0 {
+		return m, nil
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("invalid ID map: %v", err)
+	}
+	if m.Switches == nil {
+		m.Switches = make(map[string]string)
+	}
+	if m.Blocks == nil {
+		m.Blocks = make(map[string]map[string]string)
+	}
+	return m, nil
+}
+
+// ReadIDMap reads the ID map state file, or returns an empty map if the file does not exist yet
+func ReadIDMap(path string) (*IDMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			klog.Infof("ID map %q not found, allocating new switch and block names", path)
+			return NewIDMap(), nil
+		}
+		return nil, err
+	}
+	return ParseIDMap(data)
+}
+
+func (m *IDMap) Marshal() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+func (m *IDMap) clone() *IDMap {
+	copied := &IDMap{
+		Switches: make(map[string]string, len(m.Switches)),
+		Blocks:   make(map[string]map[string]string, len(m.Blocks)),
+	}
+	maps.Copy(copied.Switches, m.Switches)
+	for topoName, blocks := range m.Blocks {
+		copied.Blocks[topoName] = maps.Clone(blocks)
+	}
+	return copied
+}
+
+// allocateNames returns the names of the keys: the recorded names for the known keys,
+// and the candidate names for the new keys. A candidate name taken by another key is
+// replaced with the next free name of its numbering, e.g., block007 for block003
+// when block006 is the highest taken name. The new names are recorded.
+func allocateNames(recorded map[string]string, keys, candidates []string) []string {
+	taken := make(map[string]bool, len(recorded))
+	for _, name := range recorded {
+		taken[name] = true
+	}
+
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		if name, ok := recorded[key]; ok {
+			names[i] = name
+			continue
+		}
+		name := candidates[i]
+		if taken[name] {
+			name = nextFreeName(taken, name)
+		}
+		klog.V(4).InfoS("Allocated name", "key", key, "name", name)
+		recorded[key] = name
+		taken[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+// nextFreeName returns the name following the highest taken name of the same numbering,
+// or the name with the first free "-<n>" suffix for the names without a number
+func nextFreeName(taken map[string]bool, name string) string {
+	match := numberedNameRegexp.FindStringSubmatch(name)
+	if match == nil {
+		for n := 2; ; n++ {
+			if next := fmt.Sprintf("%s-%d", name, n); !taken[next] {
+				return next
+			}
+		}
+	}
+
+	prefix, digits := match[1], match[2]
+	highest := 0
+	for t := range taken {
+		if m := numberedNameRegexp.FindStringSubmatch(t); m != nil && m[1] == prefix {
+			n, _ := strconv.Atoi(m[2])
+			highest = max(highest, n)
+		}
+	}
+	return fmt.Sprintf("%s%0*d", prefix, len(digits), highest+1)
+}
+
+// stableSwitchNames returns the names of all switches of the tree under the ID map,
+// starting from the names under the switch naming strategy or the default names
+func (nt *NetworkTopology) stableSwitchNames(root *topology.Vertex) map[string]string {
+	vertices := make(map[string]*topology.Vertex)
+	queue := []*topology.Vertex{root}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range v.Vertices {
+			if len(w.Vertices) != 0 && w.ID != topology.NoTopology {
+				vertices[w.ID] = w
+				queue = append(queue, w)
+			}
+		}
+	}
+
+	keys := slices.Sorted(maps.Keys(vertices))
+	candidates := make([]string, 0, len(keys))
+	for _, id := range keys {
+		candidates = append(candidates, nt.vertexName(vertices[id]))
+	}
+
+	names := make(map[string]string, len(keys))
+	for i, name := range allocateNames(nt.config.IDMap.Switches, keys, candidates) {
+		names[keys[i]] = name
+	}
+	return names
+}
+
+// stableBlockNames returns the names of the blocks of the topology under the ID map,
+// starting from the candidate names
+func (nt *NetworkTopology) stableBlockNames(topoName string, blocks []*blockInfo, candidates []string) []string {
+	recorded, ok := nt.config.IDMap.Blocks[topoName]
+	if !ok {
+		recorded = make(map[string]string)
+		nt.config.IDMap.Blocks[topoName] = recorded
+	}
+
+	keys := make([]string, 0, len(blocks))
+	parts := make(map[string]int)
+	padding := 0
+	for _, bInfo := range blocks {
+		if len(bInfo.name) == 0 {
+			padding++
+			keys = append(keys, fmt.Sprintf("#padding%d", padding))
+			continue
+		}
+		parts[bInfo.name]++
+		if parts[bInfo.name] > 1 {
+			keys = append(keys, fmt.Sprintf("%s-%d", bInfo.name, parts[bInfo.name]))
+		} else {
+			keys = append(keys, bInfo.name)
+		}
+	}
+
+	return allocateNames(recorded, keys, candidates)
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestNextFreeName(t *testing.T) {
+	taken := map[string]bool{"block001": true, "block006": true, "switch.1.2": true, "rack": true, "rack-2": true}
+
+	require.Equal(t, "block007", nextFreeName(taken, "block001"))
+	require.Equal(t, "switch.1.3", nextFreeName(taken, "switch.1.2"))
+	require.Equal(t, "rack-3", nextFreeName(taken, "rack"))
+}
+
+func TestParseIDMap(t *testing.T) {
+	idMap, err := ParseIDMap(nil)
+	require.NoError(t, err)
+	require.Equal(t, NewIDMap(), idMap)
+
+	idMap, err = ParseIDMap([]byte(`{"switches":{"S1":"switch.2.1"}}`))
+	require.NoError(t, err)
+	require.Equal(t, &IDMap{
+		Switches: map[string]string{"S1": "switch.2.1"},
+		Blocks:   map[string]map[string]string{},
+	}, idMap)
+
+	_, err = ParseIDMap([]byte(`switches`))
+	require.ErrorContains(t, err, "invalid ID map")
+}
+
+func TestStableSwitchNames(t *testing.T) {
+	leaf := func(id, name string, nodes ...string) *topology.Vertex {
+		v := &topology.Vertex{ID: id, Name: name, Vertices: make(map[string]*topology.Vertex)}
+		for _, node := range nodes {
+			v.Vertices[node] = &topology.Vertex{ID: node, Name: node}
+		}
+		return v
+	}
+	graph := func(leaves ...*topology.Vertex) *topology.Graph {
+		spine := &topology.Vertex{ID: "S1", Name: "switch.2.1", Vertices: make(map[string]*topology.Vertex)}
+		for _, l := range leaves {
+			spine.Vertices[l.ID] = l
+		}
+		return &topology.Graph{Tiers: &topology.Vertex{Vertices: map[string]*topology.Vertex{"S1": spine}}}
+	}
+
+	idMap := NewIDMap()
+	cfg := &Config{Plugin: topology.TopologyTree, IDMap: idMap}
+	nt, err := NewNetworkTopology(graph(leaf("L2", "switch.1.1", "n2"), leaf("L3", "switch.1.2", "n3")), cfg)
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.Nil(t, nt.Generate(buf))
+	require.Equal(t, `# switch.2.1=S1
+SwitchName=switch.2.1 Switches=switch.1.[1-2]
+# switch.1.1=L2
+SwitchName=switch.1.1 Nodes=n2
+# switch.1.2=L3
+SwitchName=switch.1.2 Nodes=n3
+`, buf.String())
+
+	// the leaf switches renumbered by the provider after adding L1 keep their names
+	nt, err = NewNetworkTopology(graph(leaf("L1", "switch.1.1", "n1"), leaf("L2", "switch.1.2", "n2"), leaf("L3", "switch.1.3", "n3")), cfg)
+	require.NoError(t, err)
+	buf = &bytes.Buffer{}
+	require.Nil(t, nt.Generate(buf))
+	require.Equal(t, `# switch.2.1=S1
+SwitchName=switch.2.1 Switches=switch.1.[1-3]
+# switch.1.3=L1
+SwitchName=switch.1.3 Nodes=n1
+# switch.1.1=L2
+SwitchName=switch.1.1 Nodes=n2
+# switch.1.2=L3
+SwitchName=switch.1.2 Nodes=n3
+`, buf.String())
+	require.Equal(t, map[string]string{"S1": "switch.2.1", "L1": "switch.1.3", "L2": "switch.1.1", "L3": "switch.1.2"}, idMap.Switches)
+
+	spec, httpErr := nt.GetNodeTopologySpec("n1", nil)
+	require.Nil(t, httpErr)
+	require.Equal(t, "default:switch.2.1:switch.1.3", spec)
+}
+
+func TestStableBlockNames(t *testing.T) {
+	v, _ := getBlockWithDiffNumNodeTestSet()
+	idMap := NewIDMap()
+	idMap.Blocks["topo1"] = map[string]string{"B2": "block1", "B2-2": "block2"}
+	cfg := &Config{
+		Topologies: map[string]*TopologySpec{
+			"topo1": {
+				Plugin:     topology.TopologyBlock,
+				BlockSizes: []int{2},
+				Nodes:      []string{"Node[104-106,201-202,205-206]"},
+			},
+		},
+		IDMap: idMap,
+	}
+	nt, err := NewNetworkTopology(v, cfg)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, nt.Generate(buf))
+	require.Equal(t, `- topology: topo1
+  cluster_default: false
+  block:
+    block_sizes:
+        - 2
+    blocks:
+        - block: block3
+          nodes: Node[104-105]
+        - block: block4
+          nodes: Node106
+        - block: block1
+          nodes: Node[201-202]
+        - block: block2
+          nodes: Node[205-206]
+`, buf.String())
+	require.Equal(t, map[string]string{"B1": "block3", "B1-2": "block4", "B2": "block1", "B2-2": "block2"}, idMap.Blocks["topo1"])
+}
@@ -0,0 +1,284 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	JGFSubsystemContainment = "containment"
+	JGFSubsystemAccelerator = "accelerator"
+
+	JGFTypeCluster     = "cluster"
+	JGFTypeSwitch      = "switch"
+	JGFTypeNode        = "node"
+	JGFTypeCore        = "core"
+	JGFTypeGPU         = "gpu"
+	JGFTypeAccelerator = "accelerator"
+
+	jgfRelationContains = "contains"
+)
+
+// JGF is a Flux Fluxion resource graph in JSON Graph Format
+type JGF struct {
+	Graph JGFGraph `json:"graph"`
+}
+
+type JGFGraph struct {
+	Nodes []*JGFNode `json:"nodes"`
+	Edges []*JGFEdge `json:"edges"`
+}
+
+type JGFNode struct {
+	ID       string          `json:"id"`
+	Metadata JGFNodeMetadata `json:"metadata"`
+}
+
+type JGFNodeMetadata struct {
+	Type     string `json:"type"`
+	Basename string `json:"basename"`
+	Name     string `json:"name"`
+	// ID is the index of the vertex among the vertices of the same type
+	ID     int `json:"id"`
+	UniqID int `json:"uniq_id"`
+	// Rank is the Flux broker rank of the compute node, or -1
+	Rank       int               `json:"rank"`
+	Exclusive  bool              `json:"exclusive"`
+	Unit       string            `json:"unit"`
+	Size       int               `json:"size"`
+	Properties map[string]string `json:"properties,omitempty"`
+	// Paths maps the subsystems of the vertex to its path in the subsystem
+	Paths map[string]string `json:"paths"`
+}
+
+type JGFEdge struct {
+	Source   string          `json:"source"`
+	Target   string          `json:"target"`
+	Metadata JGFEdgeMetadata `json:"metadata"`
+}
+
+type JGFEdgeMetadata struct {
+	// Name maps the subsystem of the edge to the relation
+	Name map[string]string `json:"name"`
+}
+
+// NodeResources specifies the resources of a compute node
+type NodeResources struct {
+	Cores int `mapstructure:"cores"`
+	GPUs  int `mapstructure:"gpus"`
+}
+
+// JGFConfig specifies the cluster name and the compute node resources of the resource graph
+type JGFConfig struct {
+	ClusterName string
+	// Resources returns the resources of the compute node; nil means no resources
+	Resources func(nodeName string) *NodeResources
+}
+
+type jgfBuilder struct {
+	jgf     *JGF
+	cfg     *JGFConfig
+	typeIDs map[string]int
+	ranks   map[string]int
+	// node name : vertex unique ID
+	nodes map[string]int
+}
+
+// NewJGF returns the Fluxion resource graph of the topology graph.
+// The containment subsystem holds the cluster, the switch tiers, the compute nodes and
+// their cores and GPUs; compute nodes without topology are attached to the cluster.
+// The accelerator subsystem groups the compute nodes of each accelerator domain.
+// Compute node ranks follow the order of the node names.
+func NewJGF(graph *topology.Graph, cfg *JGFConfig) (*JGF, error) {
+	if graph == nil || (graph.Tiers == nil && len(graph.Domains) == 0) {
+		return nil, fmt.Errorf("no topology data")
+	}
+
+	nt := newTreeTopology(graph)
+
+	nodeSet := make(map[string]bool)
+	for nodeName := range nt.nodeInfo {
+		nodeSet[nodeName] = true
+	}
+	for _, domain := range graph.Domains {
+		for nodeName := range domain {
+			nodeSet[nodeName] = true
+		}
+	}
+	nodeNames := make([]string, 0, len(nodeSet))
+	for nodeName := range nodeSet {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+
+	b := &jgfBuilder{
+		jgf:     &JGF{Graph: JGFGraph{Nodes: []*JGFNode{}, Edges: []*JGFEdge{}}},
+		cfg:     cfg,
+		typeIDs: make(map[string]int),
+		ranks:   make(map[string]int),
+		nodes:   make(map[string]int),
+	}
+	for i, nodeName := range nodeNames {
+		b.ranks[nodeName] = i
+	}
+
+	clusterPath := "/" + cfg.ClusterName + "0"
+	cluster := b.addVertex(JGFTypeCluster, cfg.ClusterName, cfg.ClusterName+"0", -1, nil, map[string]string{
+		JGFSubsystemContainment: clusterPath,
+		JGFSubsystemAccelerator: clusterPath,
+	})
+
+	heights := nt.getHeights()
+	var visit func(string, int, string)
+	visit = func(id string, parent int, path string) {
+		for _, childID := range nt.tree[id] {
+			v := nt.vertices[childID]
+			if childID == topology.NoTopology {
+				visit(childID, parent, path)
+				continue
+			}
+			if len(nt.tree[childID]) == 0 {
+				b.addNode(v.Name, parent, path)
+				continue
+			}
+			name := v.ID
+			if len(v.Name) != 0 {
+				name = v.Name
+			}
+			swPath := path + "/" + name
+			sw := b.addVertex(JGFTypeSwitch, name, name, -1,
+				map[string]string{"tier": strconv.Itoa(heights[childID])},
+				map[string]string{JGFSubsystemContainment: swPath})
+			b.addEdge(parent, sw, JGFSubsystemContainment)
+			visit(childID, sw, swPath)
+		}
+	}
+	if graph.Tiers != nil {
+		visit("", cluster, clusterPath)
+	}
+
+	// nodes known from the accelerator domains only
+	for _, nodeName := range nodeNames {
+		if _, ok := b.nodes[nodeName]; !ok {
+			b.addNode(nodeName, cluster, clusterPath)
+		}
+	}
+
+	domainNames := make([]string, 0, len(graph.Domains))
+	for domainName := range graph.Domains {
+		domainNames = append(domainNames, domainName)
+	}
+	sort.Strings(domainNames)
+
+	for _, domainName := range domainNames {
+		domainPath := clusterPath + "/" + domainName
+		domain := b.addVertex(JGFTypeAccelerator, domainName, domainName, -1, nil,
+			map[string]string{JGFSubsystemAccelerator: domainPath})
+		b.addEdge(cluster, domain, JGFSubsystemAccelerator)
+
+		members := make([]string, 0, len(graph.Domains[domainName]))
+		for nodeName := range graph.Domains[domainName] {
+			members = append(members, nodeName)
+		}
+		sort.Strings(members)
+		for _, nodeName := range members {
+			node := b.nodes[nodeName]
+			b.jgf.Graph.Nodes[node].Metadata.Paths[JGFSubsystemAccelerator] = domainPath + "/" + nodeName
+			b.addEdge(domain, node, JGFSubsystemAccelerator)
+		}
+	}
+
+	return b.jgf, nil
+}
+
+// getHeights returns the heights of the switches above the compute nodes
+func (nt *NetworkTopology) getHeights() map[string]int {
+	heights := make(map[string]int)
+	var visit func(string) int
+	visit = func(id string) int {
+		if len(nt.tree[id]) == 0 {
+			return 0
+		}
+		if h, ok := heights[id]; ok {
+			return h
+		}
+		h := 0
+		for _, child := range nt.tree[id] {
+			h = max(h, visit(child)+1)
+		}
+		heights[id] = h
+		return h
+	}
+	visit("")
+	return heights
+}
+
+func (b *jgfBuilder) addNode(nodeName string, parent int, path string) {
+	rank := b.ranks[nodeName]
+	nodePath := path + "/" + nodeName
+	node := b.addVertexWithID(JGFTypeNode, nodeName, nodeName, rank, rank, nil,
+		map[string]string{JGFSubsystemContainment: nodePath})
+	b.addEdge(parent, node, JGFSubsystemContainment)
+	b.nodes[nodeName] = node
+
+	if b.cfg.Resources == nil {
+		return
+	}
+	res := b.cfg.Resources(nodeName)
+	if res == nil {
+		return
+	}
+	for _, r := range []struct {
+		typ   string
+		count int
+	}{{JGFTypeCore, res.Cores}, {JGFTypeGPU, res.GPUs}} {
+		for i := range r.count {
+			name := fmt.Sprintf("%s%d", r.typ, i)
+			child := b.addVertexWithID(r.typ, r.typ, name, i, rank, nil,
+				map[string]string{JGFSubsystemContainment: nodePath + "/" + name})
+			b.addEdge(node, child, JGFSubsystemContainment)
+		}
+	}
+}
+
+// addVertex adds a vertex with the next ID of its type, and returns its unique ID
+func (b *jgfBuilder) addVertex(typ, basename, name string, rank int, properties, paths map[string]string) int {
+	id := b.typeIDs[typ]
+	b.typeIDs[typ]++
+	return b.addVertexWithID(typ, basename, name, id, rank, properties, paths)
+}
+
+func (b *jgfBuilder) addVertexWithID(typ, basename, name string, id, rank int, properties, paths map[string]string) int {
+	uniqID := len(b.jgf.Graph.Nodes)
+	b.jgf.Graph.Nodes = append(b.jgf.Graph.Nodes, &JGFNode{
+		ID: strconv.Itoa(uniqID),
+		Metadata: JGFNodeMetadata{
+			Type:       typ,
+			Basename:   basename,
+			Name:       name,
+			ID:         id,
+			UniqID:     uniqID,
+			Rank:       rank,
+			Size:       1,
+			Properties: properties,
+			Paths:      paths,
+		},
+	})
+	return uniqID
+}
+
+func (b *jgfBuilder) addEdge(source, target int, subsystem string) {
+	b.jgf.Graph.Edges = append(b.jgf.Graph.Edges, &JGFEdge{
+		Source:   strconv.Itoa(source),
+		Target:   strconv.Itoa(target),
+		Metadata: JGFEdgeMetadata{Name: map[string]string{subsystem: jgfRelationContains}},
+	})
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func findJGFNode(t *testing.T, jgf *JGF, typ, name string) *JGFNode {
+	for _, node := range jgf.Graph.Nodes {
+		if node.Metadata.Type == typ && node.Metadata.Name == name {
+			return node
+		}
+	}
+	require.Failf(t, "vertex not found", "%s %s", typ, name)
+	return nil
+}
+
+func TestNewJGFWithTree(t *testing.T) {
+	graph, _ := GetTreeTestSet(false)
+	graph.Tiers.Vertices[topology.NoTopology] = &topology.Vertex{
+		ID:       topology.NoTopology,
+		Vertices: map[string]*topology.Vertex{"I50": {ID: "I50", Name: "Node050"}},
+	}
+
+	jgf, err := NewJGF(graph, &JGFConfig{ClusterName: "dgx"})
+	require.NoError(t, err)
+
+	// cluster, 3 switches, 7 nodes
+	require.Len(t, jgf.Graph.Nodes, 11)
+	require.Len(t, jgf.Graph.Edges, 10)
+
+	require.Equal(t, &JGFNode{
+		ID: "0",
+		Metadata: JGFNodeMetadata{
+			Type: JGFTypeCluster, Basename: "dgx", Name: "dgx0", Rank: -1, Size: 1,
+			Paths: map[string]string{JGFSubsystemContainment: "/dgx0", JGFSubsystemAccelerator: "/dgx0"},
+		},
+	}, jgf.Graph.Nodes[0])
+
+	s1 := findJGFNode(t, jgf, JGFTypeSwitch, "S1")
+	require.Equal(t, map[string]string{"tier": "2"}, s1.Metadata.Properties)
+	s2 := findJGFNode(t, jgf, JGFTypeSwitch, "S2")
+	require.Equal(t, map[string]string{"tier": "1"}, s2.Metadata.Properties)
+	require.Equal(t, "/dgx0/S1/S2", s2.Metadata.Paths[JGFSubsystemContainment])
+
+	node := findJGFNode(t, jgf, JGFTypeNode, "Node202")
+	require.Equal(t, 2, node.Metadata.Rank)
+	require.Equal(t, 2, node.Metadata.ID)
+	require.Equal(t, map[string]string{JGFSubsystemContainment: "/dgx0/S1/S2/Node202"}, node.Metadata.Paths)
+	require.Contains(t, jgf.Graph.Edges, &JGFEdge{
+		Source:   s2.ID,
+		Target:   node.ID,
+		Metadata: JGFEdgeMetadata{Name: map[string]string{JGFSubsystemContainment: "contains"}},
+	})
+
+	// nodes without topology are attached to the cluster
+	node = findJGFNode(t, jgf, JGFTypeNode, "Node050")
+	require.Equal(t, 0, node.Metadata.Rank)
+	require.Equal(t, "/dgx0/Node050", node.Metadata.Paths[JGFSubsystemContainment])
+}
+
+func TestNewJGFWithDomains(t *testing.T) {
+	graph, _ := GetBlockWithMultiIBTestSet()
+	resources := map[string]*NodeResources{"Node104": {Cores: 2, GPUs: 4}}
+
+	jgf, err := NewJGF(graph, &JGFConfig{
+		ClusterName: "cluster",
+		Resources:   func(nodeName string) *NodeResources { return resources[nodeName] },
+	})
+	require.NoError(t, err)
+
+	domain := findJGFNode(t, jgf, JGFTypeAccelerator, "B1")
+	require.Equal(t, map[string]string{JGFSubsystemAccelerator: "/cluster0/B1"}, domain.Metadata.Paths)
+
+	node := findJGFNode(t, jgf, JGFTypeNode, "Node104")
+	require.Equal(t, map[string]string{
+		JGFSubsystemContainment: "/cluster0/IB2/S1/S2/Node104",
+		JGFSubsystemAccelerator: "/cluster0/B1/Node104",
+	}, node.Metadata.Paths)
+	require.Contains(t, jgf.Graph.Edges, &JGFEdge{
+		Source:   domain.ID,
+		Target:   node.ID,
+		Metadata: JGFEdgeMetadata{Name: map[string]string{JGFSubsystemAccelerator: "contains"}},
+	})
+
+	cores, gpus := 0, 0
+	for _, v := range jgf.Graph.Nodes {
+		switch v.Metadata.Type {
+		case JGFTypeCore:
+			cores++
+			require.Equal(t, node.Metadata.Rank, v.Metadata.Rank)
+		case JGFTypeGPU:
+			gpus++
+		}
+	}
+	require.Equal(t, 2, cores)
+	require.Equal(t, 4, gpus)
+	gpu := findJGFNode(t, jgf, JGFTypeGPU, "gpu3")
+	require.Equal(t, 3, gpu.Metadata.ID)
+	require.Equal(t, "/cluster0/IB2/S1/S2/Node104/gpu3", gpu.Metadata.Paths[JGFSubsystemContainment])
+}
+
+func TestNewJGFWithoutTree(t *testing.T) {
+	domains := topology.NewDomainMap()
+	domains.AddHost("nvl1", "i1", "n1")
+	domains.AddHost("nvl1", "i2", "n2")
+
+	jgf, err := NewJGF(&topology.Graph{Domains: domains}, &JGFConfig{ClusterName: "cluster"})
+	require.NoError(t, err)
+
+	node := findJGFNode(t, jgf, JGFTypeNode, "n2")
+	require.Equal(t, 1, node.Metadata.Rank)
+	require.Equal(t, map[string]string{
+		JGFSubsystemContainment: "/cluster0/n2",
+		JGFSubsystemAccelerator: "/cluster0/nvl1/n2",
+	}, node.Metadata.Paths)
+
+	_, err = NewJGF(&topology.Graph{}, &JGFConfig{ClusterName: "cluster"})
+	require.EqualError(t, err, "no topology data")
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/NVIDIA/topograph/internal/cluset"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// DegradedDomain describes an accelerator domain whose usable node count
+// fell below the base block size
+type DegradedDomain struct {
+	Topology      string            `json:"topology,omitempty"`
+	Domain        string            `json:"domain"`
+	Nodes         int               `json:"nodes"`
+	UsableNodes   int               `json:"usableNodes"`
+	BaseBlockSize int               `json:"baseBlockSize"`
+	UnusableNodes map[string]string `json:"unusableNodes,omitempty"`
+}
+
+// excludeUnusableNodes returns the domain map without the unusable nodes
+func excludeUnusableNodes(domains topology.DomainMap, unusable map[string]string) topology.DomainMap {
+	if domains == nil || len(unusable) == 0 {
+		return domains
+	}
+
+	return filterDomains(domains, func(domain, host string) bool {
+		state, ok := unusable[host]
+		if ok {
+			klog.V(4).InfoS("Excluding node from block topology", "node", host, "domain", domain, "state", state)
+		}
+		return !ok
+	})
+}
+
+// filterDomains returns the domain map with the hosts accepted by keep.
+// Domains left without hosts are dropped.
+func filterDomains(domains topology.DomainMap, keep func(domain, host string) bool) topology.DomainMap {
+	filtered := topology.NewDomainMap()
+	for name, hosts := range domains {
+		for host, hostInfo := range hosts {
+			if !keep(name, host) {
+				continue
+			}
+			if _, ok := filtered[name]; !ok {
+				filtered[name] = make(map[string]*topology.HostInfo)
+			}
+			filtered[name][host] = hostInfo
+		}
+	}
+	return filtered
+}
+
+// getDegradedDomains returns the domains whose usable node count is below the base block size.
+// The base block size is the first requested block size or, if none, the smallest domain size
+// including the unusable nodes, so that node failures do not silently shrink it.
+// If nodes is not nil, the domains are restricted to these nodes.
+func getDegradedDomains(topoName string, domains topology.DomainMap, nodes []string, blockSizes []int, unusable map[string]string) []*DegradedDomain {
+	if len(domains) == 0 {
+		return nil
+	}
+
+	if nodes != nil {
+		selected := newSelector(nodes)
+		domains = filterDomains(domains, func(_, host string) bool { return selected[host] })
+	}
+
+	blocks := toBlockInfos(domains)
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	baseBlockSize := findMinDomainSize(blocks)
+	if len(blockSizes) != 0 {
+		baseBlockSize = blockSizes[0]
+	}
+
+	degraded := []*DegradedDomain{}
+	for _, block := range blocks {
+		states := make(map[string]string)
+		for _, node := range block.nodes {
+			if state, ok := unusable[node]; ok {
+				states[node] = state
+			}
+		}
+		if usable := len(block.nodes) - len(states); usable < baseBlockSize {
+			dd := &DegradedDomain{
+				Topology:      topoName,
+				Domain:        block.name,
+				Nodes:         len(block.nodes),
+				UsableNodes:   usable,
+				BaseBlockSize: baseBlockSize,
+			}
+			if len(states) != 0 {
+				dd.UnusableNodes = states
+			}
+			degraded = append(degraded, dd)
+		}
+	}
+
+	return degraded
+}
+
+// initDegradedDomains collects the degraded domains of the cluster-wide or per-partition block topologies
+func (nt *NetworkTopology) initDegradedDomains(domains topology.DomainMap) {
+	if len(nt.config.Topologies) == 0 {
+		if nt.config.Plugin == topology.TopologyBlock {
+			nt.degraded = getDegradedDomains("", domains, nil, nt.config.BlockSizes, nt.config.UnusableNodes)
+		}
+	} else {
+		names := make([]string, 0, len(nt.config.Topologies))
+		for name := range nt.config.Topologies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			spec := nt.config.Topologies[name]
+			if spec.Plugin != topology.TopologyBlock {
+				continue
+			}
+			dds := getDegradedDomains(name, domains, cluset.Expand(spec.Nodes), spec.BlockSizes, nt.config.UnusableNodes)
+			nt.degraded = append(nt.degraded, dds...)
+		}
+	}
+
+	for _, dd := range nt.degraded {
+		klog.Warningf("Accelerator domain %q has %d usable nodes out of %d, below the base block size %d",
+			dd.Domain, dd.UsableNodes, dd.Nodes, dd.BaseBlockSize)
+	}
+}
+
+// GetDegradedDomains returns the accelerator domains whose usable node count
+// fell below the base block size
+func (nt *NetworkTopology) GetDegradedDomains() []*DegradedDomain {
+	return nt.degraded
+}
+
+// unusableNodesComment returns the comment line listing the unusable nodes of the block
+func (nt *NetworkTopology) unusableNodesComment(block *blockInfo) string {
+	if nt.config.ExcludeUnusableNodes || len(nt.config.UnusableNodes) == 0 {
+		return ""
+	}
+
+	nodes := []string{}
+	for _, node := range block.nodes {
+		if state, ok := nt.config.UnusableNodes[node]; ok {
+			nodes = append(nodes, fmt.Sprintf("%s(%s)", node, state))
+		}
+	}
+	if len(nodes) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("# unusable nodes: %s\n", strings.Join(nodes, ","))
+}
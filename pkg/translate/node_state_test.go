@@ -0,0 +1,211 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestUnusableNodes(t *testing.T) {
+	unusable := map[string]string{"Node105": "DOWN", "Node106": "IDLE+DRAIN"}
+
+	testCases := []struct {
+		name     string
+		cfg      *Config
+		output   string
+		degraded []*DegradedDomain
+	}{
+		{
+			name: "Case 1: no unusable nodes",
+			cfg: &Config{
+				Plugin: topology.TopologyBlock,
+			},
+			output: `# block001=B1
+BlockName=block001 Nodes=Node[104-106]
+# block002=B2
+BlockName=block002 Nodes=Node[201-202,205]
+BlockSizes=3,6
+`,
+			degraded: []*DegradedDomain{},
+		},
+		{
+			name: "Case 2: annotate unusable nodes",
+			cfg: &Config{
+				Plugin:        topology.TopologyBlock,
+				UnusableNodes: unusable,
+			},
+			output: `# block001=B1
+# unusable nodes: Node105(DOWN),Node106(IDLE+DRAIN)
+BlockName=block001 Nodes=Node[104-106]
+# block002=B2
+BlockName=block002 Nodes=Node[201-202,205]
+BlockSizes=3,6
+`,
+			degraded: []*DegradedDomain{
+				{
+					Domain:        "B1",
+					Nodes:         3,
+					UsableNodes:   1,
+					BaseBlockSize: 3,
+					UnusableNodes: unusable,
+				},
+			},
+		},
+		{
+			name: "Case 3: exclude unusable nodes",
+			cfg: &Config{
+				Plugin:               topology.TopologyBlock,
+				UnusableNodes:        unusable,
+				ExcludeUnusableNodes: true,
+			},
+			output: `# block001=B1
+BlockName=block001 Nodes=Node104
+# block002=B2
+BlockName=block002 Nodes=Node[201-202,205]
+BlockSizes=3,6
+`,
+			degraded: []*DegradedDomain{
+				{
+					Domain:        "B1",
+					Nodes:         3,
+					UsableNodes:   1,
+					BaseBlockSize: 3,
+					UnusableNodes: unusable,
+				},
+			},
+		},
+		{
+			name: "Case 4: requested block sizes",
+			cfg: &Config{
+				Plugin:        topology.TopologyBlock,
+				BlockSizes:    []int{2},
+				UnusableNodes: map[string]string{"Node105": "DOWN"},
+			},
+			output: `# block001=B1
+# unusable nodes: Node105(DOWN)
+BlockName=block001 Nodes=Node[104-105]
+# block002=B1
+BlockName=block002 Nodes=Node106
+# block003=B2
+BlockName=block003 Nodes=Node[201-202]
+# block004=B2
+BlockName=block004 Nodes=Node205
+BlockSizes=2
+`,
+			degraded: []*DegradedDomain{},
+		},
+		{
+			name: "Case 5: per-partition topologies",
+			cfg: &Config{
+				Topologies: map[string]*TopologySpec{
+					"topo1": {
+						Plugin: topology.TopologyBlock,
+						Nodes:  []string{"Node[104-106]"},
+					},
+					"topo2": {
+						Plugin:     topology.TopologyBlock,
+						BlockSizes: []int{3},
+						Nodes:      []string{"Node[104-105]", "Node[201-202,205]"},
+					},
+				},
+				UnusableNodes: map[string]string{"Node105": "DOWN"},
+			},
+			degraded: []*DegradedDomain{
+				{
+					Topology:      "topo1",
+					Domain:        "B1",
+					Nodes:         3,
+					UsableNodes:   2,
+					BaseBlockSize: 3,
+					UnusableNodes: map[string]string{"Node105": "DOWN"},
+				},
+				{
+					Topology:      "topo2",
+					Domain:        "B1",
+					Nodes:         2,
+					UsableNodes:   1,
+					BaseBlockSize: 3,
+					UnusableNodes: map[string]string{"Node105": "DOWN"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v, _ := getBlockTestSet()
+			nt, err := NewNetworkTopology(v, tc.cfg)
+			require.NoError(t, err)
+			require.Equal(t, tc.degraded, nt.GetDegradedDomains())
+
+			if len(tc.output) != 0 {
+				buf := &bytes.Buffer{}
+				require.Nil(t, nt.Generate(buf))
+				require.Equal(t, tc.output, buf.String())
+			}
+		})
+	}
+}
+
+func TestExcludeUnusableNodesBlockSizes(t *testing.T) {
+	newGraph := func() *topology.Graph {
+		domains := topology.NewDomainMap()
+		for i := range 16 {
+			domains.AddHost(fmt.Sprintf("nvl%d", i/4), fmt.Sprintf("i%02d", i), fmt.Sprintf("n%02d", i))
+		}
+		return &topology.Graph{Domains: domains}
+	}
+	blockSizes := func(cfg *Config) string {
+		nt, err := NewNetworkTopology(newGraph(), cfg)
+		require.NoError(t, err)
+		buf := &bytes.Buffer{}
+		require.Nil(t, nt.Generate(buf))
+		for _, line := range strings.Split(buf.String(), "\n") {
+			if sizes, ok := strings.CutPrefix(line, "BlockSizes="); ok {
+				return sizes
+			}
+		}
+		return ""
+	}
+
+	testCases := []struct {
+		name     string
+		unusable map[string]string
+	}{
+		{
+			name:     "Case 1: one unusable node",
+			unusable: map[string]string{"n00": "DOWN"},
+		},
+		{
+			name:     "Case 2: unusable domain",
+			unusable: map[string]string{"n04": "DOWN", "n05": "DOWN", "n06": "DOWN", "n07": "DOWN"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, "4,8,16", blockSizes(&Config{Plugin: topology.TopologyBlock}))
+			require.Equal(t, "4,8,16", blockSizes(&Config{
+				Plugin:               topology.TopologyBlock,
+				UnusableNodes:        tc.unusable,
+				ExcludeUnusableNodes: true,
+			}))
+			require.Equal(t, "4,8,16", blockSizes(&Config{
+				Plugin:               topology.TopologyBlock,
+				AutoBlockSizes:       true,
+				UnusableNodes:        tc.unusable,
+				ExcludeUnusableNodes: true,
+			}))
+		})
+	}
+}
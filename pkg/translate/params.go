@@ -0,0 +1,59 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"maps"
+
+	"github.com/NVIDIA/topograph/internal/config"
+)
+
+// BlockSizesAutoOptimal is the blockSizes parameter value selecting
+// the block sizes recommended by the block size planner
+const BlockSizesAutoOptimal = "auto-optimal"
+
+const (
+	keyBlockSizes = "blockSizes"
+	keyTopologies = "topologies"
+)
+
+// DecodeParams decodes the engine parameters into p, accepting "auto-optimal" as the blockSizes
+// of the cluster-wide and per-partition topologies. It returns the topologies with "auto-optimal"
+// block sizes: "" for the cluster-wide topology, and the names of the per-partition topologies.
+func DecodeParams(params map[string]any, p any) (map[string]bool, error) {
+	auto := make(map[string]bool)
+	if err := config.Decode(resolveAutoBlockSizes(params, "", auto), p); err != nil {
+		return nil, err
+	}
+	return auto, nil
+}
+
+// resolveAutoBlockSizes returns a copy of the parameters without blockSizes set to "auto-optimal",
+// so that blockSizes decodes as a list, and records the topology in auto
+func resolveAutoBlockSizes(params map[string]any, topoName string, auto map[string]bool) map[string]any {
+	if params == nil {
+		return nil
+	}
+
+	resolved := maps.Clone(params)
+	if bs, ok := params[keyBlockSizes].(string); ok && bs == BlockSizesAutoOptimal {
+		delete(resolved, keyBlockSizes)
+		auto[topoName] = true
+	}
+
+	if topologies, ok := params[keyTopologies].(map[string]any); ok && len(topoName) == 0 {
+		resolvedTopologies := make(map[string]any, len(topologies))
+		for name, topo := range topologies {
+			if m, ok := topo.(map[string]any); ok {
+				topo = resolveAutoBlockSizes(m, name, auto)
+			}
+			resolvedTopologies[name] = topo
+		}
+		resolved[keyTopologies] = resolvedTopologies
+	}
+
+	return resolved
+}
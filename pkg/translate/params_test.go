@@ -0,0 +1,49 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeParams(t *testing.T) {
+	type topo struct {
+		BlockSizes     []int `mapstructure:"blockSizes"`
+		AutoBlockSizes bool  `mapstructure:"-"`
+	}
+	type params struct {
+		BlockSizes     []int            `mapstructure:"blockSizes"`
+		AutoBlockSizes bool             `mapstructure:"-"`
+		Topologies     map[string]*topo `mapstructure:"topologies"`
+	}
+
+	in := map[string]any{
+		"blockSizes": "auto-optimal",
+		"topologies": map[string]any{
+			"topo1": map[string]any{"blockSizes": "auto-optimal"},
+			"topo2": map[string]any{"blockSizes": []int{8, 16}},
+		},
+	}
+	var p params
+	auto, err := DecodeParams(in, &p)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"": true, "topo1": true}, auto)
+	require.Equal(t, params{Topologies: map[string]*topo{"topo1": {}, "topo2": {BlockSizes: []int{8, 16}}}}, p)
+	// the parameters are not modified
+	require.Equal(t, "auto-optimal", in["blockSizes"])
+
+	// the derived flag is not a parameter
+	p = params{}
+	auto, err = DecodeParams(map[string]any{"autoBlockSizes": true}, &p)
+	require.NoError(t, err)
+	require.Empty(t, auto)
+	require.False(t, p.AutoBlockSizes)
+
+	_, err = DecodeParams(map[string]any{"blockSizes": "auto"}, &p)
+	require.Error(t, err)
+}
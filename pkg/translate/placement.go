@@ -0,0 +1,157 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/NVIDIA/topograph/internal/httperr"
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+// NodePlacement describes the network location of a compute node
+type NodePlacement struct {
+	// Switches lists the names of the switches above the node, starting from the leaf switch
+	Switches []string
+	// Domain is the accelerator domain of the node, if any
+	Domain string
+	// Block is the block ID of the accelerator domain in the block topology, if any
+	Block string
+	// InstanceID is the instance ID of the node
+	InstanceID string
+}
+
+// GetNodePlacements returns the network location of each compute node, keyed by node name.
+// Nodes without network topology have no switches.
+func GetNodePlacements(graph *topology.Graph) map[string]*NodePlacement {
+	if graph == nil {
+		return make(map[string]*NodePlacement)
+	}
+
+	return newTreeTopology(graph).nodePlacements(graph, toBlockInfos(graph.Domains))
+}
+
+// GetNamedNodePlacements returns the network location of each compute node, with the switch
+// and block names under the naming strategies and the ID map of the configuration,
+// as in the SLURM topology config. The ID map is not updated.
+func GetNamedNodePlacements(graph *topology.Graph, cfg *Config) (map[string]*NodePlacement, *httperr.Error) {
+	if graph == nil {
+		return make(map[string]*NodePlacement), nil
+	}
+
+	if cfg.IDMap != nil {
+		copied := *cfg
+		copied.IDMap = cfg.IDMap.clone()
+		cfg = &copied
+	}
+
+	nt := newTreeTopology(graph)
+	nt.config = cfg
+
+	root := graph.ToTree().Tiers
+	names, err := cfg.Naming.SwitchNames(root)
+	if err != nil {
+		return nil, httperr.NewError(http.StatusBadRequest, err.Error())
+	}
+	nt.switchNames = names
+	if cfg.IDMap != nil && root != nil {
+		nt.switchNames = nt.stableSwitchNames(root)
+	}
+
+	blocks, httpErr := nt.nameBlocks("", toBlockInfos(graph.Domains), "")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return nt.nodePlacements(graph, blocks), nil
+}
+
+func (nt *NetworkTopology) nodePlacements(graph *topology.Graph, blocks []*blockInfo) map[string]*NodePlacement {
+	res := make(map[string]*NodePlacement)
+	for nodeName, info := range nt.nodeInfo {
+		placement := &NodePlacement{InstanceID: info.instanceID}
+		for i := len(info.switches) - 1; i >= 0; i-- {
+			id := info.switches[i]
+			if id == topology.NoTopology {
+				continue
+			}
+			name := id
+			if v, ok := nt.vertices[id]; ok {
+				name = nt.vertexName(v)
+			}
+			placement.Switches = append(placement.Switches, name)
+		}
+		res[nodeName] = placement
+	}
+
+	for _, block := range blocks {
+		for _, nodeName := range block.nodes {
+			placement, ok := res[nodeName]
+			if !ok {
+				placement = &NodePlacement{}
+				res[nodeName] = placement
+			}
+			if hostInfo := graph.Domains[block.name][nodeName]; hostInfo != nil && len(placement.InstanceID) == 0 {
+				placement.InstanceID = hostInfo.InstanceID
+			}
+			placement.Domain = block.name
+			placement.Block = block.id
+		}
+	}
+
+	return res
+}
+
+// GetNodeOrder returns the compute node names ordered by network proximity.
+// The nodes of an accelerator domain are kept together, and the domains and the remaining
+// nodes follow their leaf and spine switches, as the blocks of the block topology do.
+// Nodes without network topology come last.
+func GetNodeOrder(graph *topology.Graph) []string {
+	placements := GetNodePlacements(graph)
+
+	// paths hold the switches from the top tier down to the leaf switch
+	paths := make(map[string][]string, len(placements))
+	for nodeName, placement := range placements {
+		path := slices.Clone(placement.Switches)
+		slices.Reverse(path)
+		paths[nodeName] = path
+	}
+
+	less := func(a, b string) bool {
+		pa, pb := paths[a], paths[b]
+		if (len(pa) == 0) != (len(pb) == 0) {
+			return len(pb) == 0
+		}
+		if c := slices.Compare(pa, pb); c != 0 {
+			return c < 0
+		}
+		return a < b
+	}
+
+	// a unit is either an accelerator domain or a single node outside of the domains
+	units := [][]string{}
+	domains := make(map[string][]string)
+	for nodeName, placement := range placements {
+		if len(placement.Domain) == 0 {
+			units = append(units, []string{nodeName})
+		} else {
+			domains[placement.Domain] = append(domains[placement.Domain], nodeName)
+		}
+	}
+	for _, nodes := range domains {
+		sort.Slice(nodes, func(i, j int) bool { return less(nodes[i], nodes[j]) })
+		units = append(units, nodes)
+	}
+	sort.Slice(units, func(i, j int) bool { return less(units[i][0], units[j][0]) })
+
+	order := make([]string, 0, len(placements))
+	for _, unit := range units {
+		order = append(order, unit...)
+	}
+	return order
+}
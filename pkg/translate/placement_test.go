@@ -0,0 +1,79 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestGetNodePlacements(t *testing.T) {
+	graph, _ := GetBlockWithMultiIBTestSet()
+	graph.Tiers.Vertices[topology.NoTopology] = &topology.Vertex{
+		ID:       topology.NoTopology,
+		Vertices: map[string]*topology.Vertex{"I50": {ID: "I50", Name: "Node050"}},
+	}
+
+	placements := GetNodePlacements(graph)
+	require.Len(t, placements, 13)
+	require.Equal(t, &NodePlacement{Switches: []string{"S2", "S1", "IB2"}, Domain: "B1", Block: "block001", InstanceID: "I14"}, placements["Node104"])
+	require.Equal(t, &NodePlacement{Switches: []string{"S6", "S4", "IB1"}, Domain: "B4", Block: "block004", InstanceID: "I43"}, placements["Node403"])
+	require.Equal(t, &NodePlacement{InstanceID: "I50"}, placements["Node050"])
+
+	require.Empty(t, GetNodePlacements(nil))
+}
+
+func TestGetNamedNodePlacements(t *testing.T) {
+	graph, _ := GetBlockWithMultiIBTestSet()
+
+	idMap := NewIDMap()
+	idMap.Blocks[""] = map[string]string{"B4": "block009"}
+	cfg := &Config{Naming: topology.Naming{Switches: "id"}, IDMap: idMap}
+	placements, err := GetNamedNodePlacements(graph, cfg)
+	require.Nil(t, err)
+	require.Len(t, placements, 12)
+	require.Equal(t, &NodePlacement{Switches: []string{"S2", "S1", "IB2"}, Domain: "B1", Block: "block001", InstanceID: "I14"}, placements["Node104"])
+	require.Equal(t, &NodePlacement{Switches: []string{"S6", "S4", "IB1"}, Domain: "B4", Block: "block009", InstanceID: "I43"}, placements["Node403"])
+
+	// the ID map is not updated
+	require.Equal(t, map[string]string{"B4": "block009"}, idMap.Blocks[""])
+	require.Empty(t, idMap.Switches)
+
+	_, err = GetNamedNodePlacements(graph, &Config{Naming: topology.Naming{Blocks: "{bad}"}})
+	require.NotNil(t, err)
+
+	placements, err = GetNamedNodePlacements(nil, cfg)
+	require.Nil(t, err)
+	require.Empty(t, placements)
+}
+
+func TestGetNodeOrder(t *testing.T) {
+	graph, _ := GetBlockWithMultiIBTestSet()
+	graph.Tiers.Vertices[topology.NoTopology] = &topology.Vertex{
+		ID:       topology.NoTopology,
+		Vertices: map[string]*topology.Vertex{"I50": {ID: "I50", Name: "Node050"}},
+	}
+
+	require.Equal(t, []string{
+		"Node301", "Node302", "Node303", // B3 under IB1/S4/S5
+		"Node401", "Node402", "Node403", // B4 under IB1/S4/S6
+		"Node104", "Node105", "Node106", // B1 under IB2/S1/S2
+		"Node201", "Node202", "Node205", // B2 under IB2/S1/S3
+		"Node050",
+	}, GetNodeOrder(graph))
+
+	// domain spanning two leaf switches
+	graph, _ = GetTreeTestSet(false)
+	graph.Domains = topology.DomainMap{
+		"nvl1": {"Node205": {}, "Node304": {}},
+	}
+	require.Equal(t, []string{"Node201", "Node202", "Node205", "Node304", "Node305", "Node306"}, GetNodeOrder(graph))
+
+	require.Empty(t, GetNodeOrder(nil))
+}
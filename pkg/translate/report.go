@@ -0,0 +1,312 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+const (
+	OutlierKindSwitch = "switch"
+	OutlierKindDomain = "domain"
+
+	OutlierMetricNodes   = "nodes"
+	OutlierMetricUplinks = "uplinks"
+)
+
+// Report summarizes the network topology for capacity planning
+type Report struct {
+	// Tiers describes the switches of each tier, starting from the leaf switches
+	Tiers []*TierReport `json:"tiers,omitempty"`
+	// Domains describes the sizes of the accelerator domains
+	Domains *DomainReport `json:"domains,omitempty"`
+	// Blocks lists the blocks of the block topology, including padding blocks
+	Blocks     []*BlockReport `json:"blocks,omitempty"`
+	BlockSizes []int          `json:"blockSizes,omitempty"`
+	// BlockPlan evaluates the candidate block sizes and recommends the best one
+	BlockPlan *BlockPlan `json:"blockPlan,omitempty"`
+	// NoTopology lists the nodes without network topology data
+	NoTopology []string `json:"noTopology,omitempty"`
+	// Links lists the link statistics of the switches, sorted by tier and ID
+	Links []*SwitchLinkReport `json:"links,omitempty"`
+	// Outliers lists the switches and domains that differ from their peers
+	Outliers []*Outlier `json:"outliers,omitempty"`
+}
+
+// TierReport describes the switches of a tier
+type TierReport struct {
+	// Tier is the switch height above the compute nodes, starting from 1 for leaf switches
+	Tier     int `json:"tier"`
+	Switches int `json:"switches"`
+	// FanOut is the distribution of the number of connected lower-tier vertices per switch
+	FanOut *Distribution `json:"fanOut"`
+	// Nodes is the distribution of the number of compute nodes below each switch
+	Nodes *Distribution `json:"nodes"`
+}
+
+// DomainReport describes the accelerator domains
+type DomainReport struct {
+	Count int `json:"count"`
+	// Sizes is the distribution of the number of nodes per domain
+	Sizes *Distribution `json:"sizes"`
+}
+
+// BlockReport describes a block of the block topology.
+// Padding blocks are empty base blocks added to align the block tree to the block sizes.
+type BlockReport struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Nodes   int    `json:"nodes"`
+	Padding bool   `json:"padding,omitempty"`
+}
+
+// SwitchLinkReport describes the links of a switch.
+// The data rates are aggregated in Gb/s, and omitted if unknown.
+// Oversubscription is the ratio of downlink to uplink capacity, omitted for switches without uplinks.
+type SwitchLinkReport struct {
+	ID               string  `json:"id"`
+	Tier             int     `json:"tier"`
+	Downlinks        int     `json:"downlinks"`
+	Uplinks          int     `json:"uplinks"`
+	DownlinkRate     float64 `json:"downlinkRate,omitempty"`
+	UplinkRate       float64 `json:"uplinkRate,omitempty"`
+	Oversubscription float64 `json:"oversubscription,omitempty"`
+}
+
+// Outlier is a switch or a domain with a metric below the most common value among its peers
+type Outlier struct {
+	Kind     string `json:"kind"`
+	ID       string `json:"id"`
+	Tier     int    `json:"tier,omitempty"`
+	Metric   string `json:"metric"`
+	Value    int    `json:"value"`
+	Expected int    `json:"expected"`
+}
+
+// Distribution summarizes a set of values.
+// Histogram maps each value to the number of its occurrences.
+type Distribution struct {
+	Min       int         `json:"min"`
+	Max       int         `json:"max"`
+	Mean      float64     `json:"mean"`
+	Histogram map[int]int `json:"histogram"`
+}
+
+func newDistribution(values []int) *Distribution {
+	d := &Distribution{Histogram: make(map[int]int)}
+	if len(values) == 0 {
+		return d
+	}
+
+	d.Min, d.Max = slices.Min(values), slices.Max(values)
+	sum := 0
+	for _, val := range values {
+		sum += val
+		d.Histogram[val]++
+	}
+	d.Mean = math.Round(float64(sum)/float64(len(values))*100) / 100
+
+	return d
+}
+
+// mode returns the most common value, preferring the larger value on ties
+func (d *Distribution) mode() int {
+	mode, count := 0, 0
+	for val, n := range d.Histogram {
+		if n > count || (n == count && val > mode) {
+			mode, count = val, n
+		}
+	}
+	return mode
+}
+
+// NewReport returns the statistics of the topology graph: switch fan-out and node
+// counts per tier, accelerator domain sizes, blocks, nodes without topology, switch
+// links, and switches and domains that are under-populated or under-cabled compared to their peers.
+func NewReport(graph *topology.Graph, cfg *Config) (*Report, error) {
+	nt, err := NewNetworkTopology(graph, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Report{}
+	nt.reportTiers(r)
+	nt.reportDomains(r)
+	nt.reportBlocks(r)
+
+	// links are counted on the original graph, before it is forced into a tree
+	reportLinks(r, graph.Tiers)
+	for _, sw := range topology.GetHalfCabledSwitches(graph.Tiers) {
+		r.Outliers = append(r.Outliers, &Outlier{
+			Kind:     OutlierKindSwitch,
+			ID:       sw.ID,
+			Tier:     sw.Tier,
+			Metric:   OutlierMetricUplinks,
+			Value:    sw.Uplinks,
+			Expected: sw.ExpectedUplinks,
+		})
+	}
+
+	slices.SortFunc(r.Outliers, func(a, b *Outlier) int {
+		return cmp.Or(
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Tier, b.Tier),
+			cmp.Compare(a.Metric, b.Metric),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+
+	return r, nil
+}
+
+func (nt *NetworkTopology) reportTiers(r *Report) {
+	heights := make(map[string]int)
+	nodes := make(map[string]int)
+	var visit func(string) (int, int)
+	visit = func(id string) (int, int) {
+		children := nt.tree[id]
+		if len(children) == 0 {
+			return 0, 1
+		}
+		if h, ok := heights[id]; ok {
+			return h, nodes[id]
+		}
+		height, count := 0, 0
+		for _, child := range children {
+			h, n := visit(child)
+			height = max(height, h+1)
+			count += n
+		}
+		heights[id], nodes[id] = height, count
+		return height, count
+	}
+
+	for _, id := range nt.tree[""] {
+		if id == topology.NoTopology {
+			for _, node := range nt.tree[id] {
+				r.NoTopology = append(r.NoTopology, nt.vertices[node].Name)
+			}
+			continue
+		}
+		visit(id)
+	}
+	sort.Strings(r.NoTopology)
+
+	tiers := make(map[int][]string)
+	for id, h := range heights {
+		tiers[h] = append(tiers[h], id)
+	}
+
+	for h := 1; h <= len(tiers); h++ {
+		switches := tiers[h]
+		sort.Strings(switches)
+		fanOut := make([]int, 0, len(switches))
+		counts := make([]int, 0, len(switches))
+		for _, id := range switches {
+			fanOut = append(fanOut, len(nt.tree[id]))
+			counts = append(counts, nodes[id])
+		}
+
+		tier := &TierReport{
+			Tier:     h,
+			Switches: len(switches),
+			FanOut:   newDistribution(fanOut),
+			Nodes:    newDistribution(counts),
+		}
+		r.Tiers = append(r.Tiers, tier)
+
+		expected := tier.Nodes.mode()
+		for _, id := range switches {
+			if nodes[id] < expected {
+				r.Outliers = append(r.Outliers, &Outlier{
+					Kind:     OutlierKindSwitch,
+					ID:       id,
+					Tier:     h,
+					Metric:   OutlierMetricNodes,
+					Value:    nodes[id],
+					Expected: expected,
+				})
+			}
+		}
+	}
+}
+
+func reportLinks(r *Report, root *topology.Vertex) {
+	for _, sw := range topology.GetSwitchLinks(root) {
+		r.Links = append(r.Links, &SwitchLinkReport{
+			ID:               sw.ID,
+			Tier:             sw.Tier,
+			Downlinks:        sw.Downlinks,
+			Uplinks:          sw.Uplinks,
+			DownlinkRate:     sw.DownlinkRate,
+			UplinkRate:       sw.UplinkRate,
+			Oversubscription: math.Round(sw.Oversubscription()*100) / 100,
+		})
+	}
+	slices.SortFunc(r.Links, func(a, b *SwitchLinkReport) int {
+		return cmp.Or(cmp.Compare(a.Tier, b.Tier), cmp.Compare(a.ID, b.ID))
+	})
+}
+
+func (nt *NetworkTopology) reportDomains(r *Report) {
+	if len(nt.domains) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(nt.domains))
+	for name := range nt.domains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sizes := make([]int, 0, len(names))
+	for _, name := range names {
+		sizes = append(sizes, len(nt.domains[name]))
+	}
+	r.Domains = &DomainReport{Count: len(names), Sizes: newDistribution(sizes)}
+
+	expected := r.Domains.Sizes.mode()
+	for i, name := range names {
+		if sizes[i] < expected {
+			r.Outliers = append(r.Outliers, &Outlier{
+				Kind:     OutlierKindDomain,
+				ID:       name,
+				Metric:   OutlierMetricNodes,
+				Value:    sizes[i],
+				Expected: expected,
+			})
+		}
+	}
+}
+
+func (nt *NetworkTopology) reportBlocks(r *Report) {
+	blocks := nt.complementBlocks(nt.blocks, nt.config.BlockSizes)
+	if len(blocks) == 0 {
+		return
+	}
+
+	for _, block := range blocks {
+		r.Blocks = append(r.Blocks, &BlockReport{
+			ID:      block.id,
+			Name:    block.name,
+			Nodes:   len(block.nodes),
+			Padding: len(block.nodes) == 0,
+		})
+	}
+	r.BlockSizes = getBlockSizes(blocks, nt.config.BlockSizes)
+
+	// planned block sizes are recommended rather than requested
+	var requested []int
+	if !nt.config.AutoBlockSizes {
+		requested = nt.config.BlockSizes
+	}
+	r.BlockPlan = PlanBlockSizes(nt.domains, requested)
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2026 NVIDIA CORPORATION
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package translate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/topograph/pkg/topology"
+)
+
+func TestNewReport(t *testing.T) {
+	graph, _ := getBlockWithDiffNumNodeTestSet()
+	graph.Tiers.Vertices[topology.NoTopology] = &topology.Vertex{
+		ID: topology.NoTopology,
+		Vertices: map[string]*topology.Vertex{
+			"I31": {ID: "I31", Name: "Node301"},
+			"I30": {ID: "I30", Name: "Node300"},
+		},
+	}
+
+	report, err := NewReport(graph, &Config{Plugin: topology.TopologyTree})
+	require.NoError(t, err)
+
+	expected := &Report{
+		Tiers: []*TierReport{
+			{
+				Tier:     1,
+				Switches: 2,
+				FanOut:   &Distribution{Min: 3, Max: 4, Mean: 3.5, Histogram: map[int]int{3: 1, 4: 1}},
+				Nodes:    &Distribution{Min: 3, Max: 4, Mean: 3.5, Histogram: map[int]int{3: 1, 4: 1}},
+			},
+			{
+				Tier:     2,
+				Switches: 1,
+				FanOut:   &Distribution{Min: 2, Max: 2, Mean: 2, Histogram: map[int]int{2: 1}},
+				Nodes:    &Distribution{Min: 7, Max: 7, Mean: 7, Histogram: map[int]int{7: 1}},
+			},
+		},
+		Domains: &DomainReport{
+			Count: 2,
+			Sizes: &Distribution{Min: 3, Max: 4, Mean: 3.5, Histogram: map[int]int{3: 1, 4: 1}},
+		},
+		Blocks: []*BlockReport{
+			{ID: "block001", Name: "B1", Nodes: 3},
+			{ID: "block002", Name: "B2", Nodes: 4},
+		},
+		BlockSizes: []int{3, 6},
+		BlockPlan:  PlanBlockSizes(graph.Domains, nil),
+		NoTopology: []string{"Node300", "Node301"},
+		Links: []*SwitchLinkReport{
+			{ID: "S2", Tier: 1, Downlinks: 3, Uplinks: 1, Oversubscription: 3},
+			{ID: "S3", Tier: 1, Downlinks: 4, Uplinks: 1, Oversubscription: 4},
+			{ID: "S1", Tier: 2, Downlinks: 2},
+		},
+		Outliers: []*Outlier{
+			{Kind: OutlierKindDomain, ID: "B1", Metric: OutlierMetricNodes, Value: 3, Expected: 4},
+			{Kind: OutlierKindSwitch, ID: "S2", Tier: 1, Metric: OutlierMetricNodes, Value: 3, Expected: 4},
+		},
+	}
+	require.Equal(t, expected, report)
+}
+
+func TestNewReportPadding(t *testing.T) {
+	graph, _ := getBlockWithIBTestSet()
+	delete(graph.Domains, "B2")
+
+	report, err := NewReport(graph, &Config{Plugin: topology.TopologyBlock, BlockSizes: []int{4, 8, 16}})
+	require.NoError(t, err)
+
+	require.Equal(t, []*BlockReport{
+		{ID: "block001", Name: "B1", Nodes: 3},
+		{ID: "block002", Name: "B3", Nodes: 3},
+		{ID: "block003", Name: "B4", Nodes: 3},
+		{ID: "block004", Nodes: 0, Padding: true},
+	}, report.Blocks)
+	require.Equal(t, []int{4, 8, 16}, report.BlockSizes)
+}
+
+func TestNewReportUplinks(t *testing.T) {
+	//
+	//      S1     S2
+	//     /  \   /
+	//   L2    L1
+	//   |     |
+	//   n2    n1
+	//
+	n1 := &topology.Vertex{ID: "n1", Name: "node1"}
+	n2 := &topology.Vertex{ID: "n2", Name: "node2"}
+	l1 := &topology.Vertex{ID: "L1", Vertices: map[string]*topology.Vertex{"n1": n1}}
+	l2 := &topology.Vertex{ID: "L2", Vertices: map[string]*topology.Vertex{"n2": n2}}
+	l1.Links = map[string]*topology.Link{"n1": {Count: 2, Rate: 400}}
+	l2.Links = map[string]*topology.Link{"n2": {Count: 2, Rate: 400}}
+	ndr := func(count int) *topology.Link { return &topology.Link{Count: count, Rate: 400} }
+	graph := &topology.Graph{Tiers: &topology.Vertex{Vertices: map[string]*topology.Vertex{
+		"S1": {
+			ID:       "S1",
+			Vertices: map[string]*topology.Vertex{"L1": l1, "L2": l2},
+			Links:    map[string]*topology.Link{"L1": ndr(1), "L2": ndr(1)},
+		},
+		"S2": {
+			ID:       "S2",
+			Vertices: map[string]*topology.Vertex{"L1": l1},
+			Links:    map[string]*topology.Link{"L1": ndr(1)},
+		},
+	}}}
+
+	report, err := NewReport(graph, &Config{Plugin: topology.TopologyTree})
+	require.NoError(t, err)
+	require.Equal(t, []*SwitchLinkReport{
+		{ID: "L1", Tier: 1, Downlinks: 2, Uplinks: 2, DownlinkRate: 800, UplinkRate: 800, Oversubscription: 1},
+		{ID: "L2", Tier: 1, Downlinks: 2, Uplinks: 1, DownlinkRate: 800, UplinkRate: 400, Oversubscription: 2},
+		{ID: "S1", Tier: 2, Downlinks: 2, DownlinkRate: 800},
+		{ID: "S2", Tier: 2, Downlinks: 1, DownlinkRate: 400},
+	}, report.Links)
+	require.Equal(t, []*Outlier{
+		{Kind: OutlierKindSwitch, ID: "L2", Tier: 1, Metric: OutlierMetricUplinks, Value: 1, Expected: 2},
+	}, report.Outliers)
+}
+
+func TestDistributionMode(t *testing.T) {
+	require.Equal(t, 16, newDistribution([]int{14, 16, 16, 15}).mode())
+	require.Equal(t, 16, newDistribution([]int{14, 16}).mode())
+	require.Equal(t, 0, newDistribution(nil).mode())
+}
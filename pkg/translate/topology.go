@@ -22,14 +22,31 @@ type Config struct {
 	Plugin     string // topology plugin (cluster-wide)
 	BlockSizes []int
 	Topologies map[string]*TopologySpec // per-partiton topology settings
+	// UnusableNodes maps the names of the nodes not available for jobs to their states
+	UnusableNodes map[string]string
+	// ExcludeUnusableNodes removes the unusable nodes from the blocks;
+	// otherwise they are kept and annotated
+	ExcludeUnusableNodes bool
+	// AutoBlockSizes replaces empty BlockSizes with the sizes recommended by PlanBlockSizes
+	AutoBlockSizes bool
+	// Explain records the decision trail of the node placements, see NetworkTopology.Explain
+	Explain bool
+	// Naming defines the switch and block names; empty strategies keep the default names
+	Naming topology.Naming
+	// IDMap (optional) keeps the switch and block names of the previous generations;
+	// the names allocated for the new switches and blocks are added to it
+	IDMap *IDMap
 }
 
 // TopologySpec define topology for a partition
 type TopologySpec struct {
 	Plugin         string
 	BlockSizes     []int
+	AutoBlockSizes bool // replace empty BlockSizes with the sizes recommended by PlanBlockSizes
 	ClusterDefault bool
 	Nodes          []string
+	TrimTiers      int  // number of highest switch tiers to trim from the tree
+	LeafOnly       bool // keep only the leaf switches in the tree
 }
 
 type NetworkTopology struct {
@@ -39,13 +56,18 @@ type NetworkTopology struct {
 	blocks   []*blockInfo                // blocks
 	vertices map[string]*topology.Vertex // object ID to Vertex map
 	nodeInfo map[string]*nodeInfo        // node name to nodeInfo map
+	degraded []*DegradedDomain           // domains with less usable nodes than the base block size
+	trail    map[string]*NodeExplanation // node name to decision trail in explain mode
+	// switch ID to name map under the switch naming strategy, nil for the default naming
+	switchNames map[string]string
 }
 
 type blockInfo struct {
-	id    string
-	name  string
-	indx  int
-	nodes []string
+	id        string
+	name      string
+	indx      int
+	nodes     []string
+	placement *basePlacement // position in the block tree in explain mode
 }
 
 type nodeInfo struct {
@@ -101,19 +123,69 @@ func NewNetworkTopology(graph *topology.Graph, cfg *Config) (*NetworkTopology, e
 		return nil, err
 	}
 
+	var parents map[string][]string
+	if cfg.Explain && graph != nil {
+		parents = getParents(graph.Tiers)
+	}
+
+	// SLURM topology requires a strict tree of switches
+	graph = graph.ToTree()
+
 	nt := &NetworkTopology{
 		config:   cfg,
 		tree:     make(map[string][]string),
 		vertices: make(map[string]*topology.Vertex),
 		nodeInfo: make(map[string]*nodeInfo),
 	}
+	if cfg.Explain {
+		nt.trail = make(map[string]*NodeExplanation)
+	}
+
+	if graph != nil {
+		names, err := cfg.Naming.SwitchNames(graph.Tiers)
+		if err != nil {
+			return nil, err
+		}
+		nt.switchNames = names
+		if cfg.IDMap != nil && graph.Tiers != nil {
+			nt.switchNames = nt.stableSwitchNames(graph.Tiers)
+		}
+
+		nt.explainTree(graph.Tiers, parents)
+		nt.explainDomains(graph.Domains)
+
+		// block sizes are derived from all the nodes, so that excluding the unusable nodes
+		// does not shrink them, and the excluded nodes leave padding instead
+		nt.config = cfg.withPlannedBlockSizes(graph.Domains)
+		nt.initDegradedDomains(graph.Domains)
+		if cfg.ExcludeUnusableNodes && len(cfg.UnusableNodes) != 0 {
+			nt.config = nt.config.withDefaultBlockSizes(graph.Domains)
+			filtered := *graph
+			filtered.Domains = excludeUnusableNodes(graph.Domains, cfg.UnusableNodes)
+			graph = &filtered
+		}
+	}
 
 	nt.initTree(graph)
 	nt.initBlocks(graph)
+	nt.explainBlockOrder(graph != nil && graph.Tiers != nil)
 
 	return nt, nil
 }
 
+// newTreeTopology returns the network topology with the switch tree only,
+// for the outputs that do not depend on a topology plugin
+func newTreeTopology(graph *topology.Graph) *NetworkTopology {
+	nt := &NetworkTopology{
+		config:   &Config{},
+		tree:     make(map[string][]string),
+		vertices: make(map[string]*topology.Vertex),
+		nodeInfo: make(map[string]*nodeInfo),
+	}
+	nt.initTree(graph.ToTree())
+	return nt
+}
+
 func (nt *NetworkTopology) initTree(graph *topology.Graph) {
 	if graph == nil || graph.Tiers == nil {
 		return
@@ -306,7 +378,11 @@ func (nt *NetworkTopology) GetNodeTopologySpec(node string, topologies []*Topolo
 		case topology.TopologyBlock:
 			return fmt.Sprintf("default:%s", nodeInfo.blockID), nil
 		case topology.TopologyTree:
-			return fmt.Sprintf("default:%s", strings.Join(nodeInfo.switches, ":")), nil
+			switches := make([]string, 0, len(nodeInfo.switches))
+			for _, id := range nodeInfo.switches {
+				switches = append(switches, nt.switchName(id))
+			}
+			return fmt.Sprintf("default:%s", strings.Join(switches, ":")), nil
 		default:
 			return "", nil
 		}
@@ -237,6 +237,34 @@ func TestToTreeTopology(t *testing.T) {
 	require.Equal(t, testTreeConfig, buf.String())
 }
 
+func TestToTreeTopologyNaming(t *testing.T) {
+	v, _ := GetTreeTestSet(false)
+	cfg := &Config{
+		Plugin: topology.TopologyTree,
+		Naming: topology.Naming{Switches: "sw{tier}-{index}"},
+	}
+	nt, err := NewNetworkTopology(v, cfg)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, nt.Generate(buf))
+	require.Equal(t, `# sw2-1=S1
+SwitchName=sw2-1 Switches=sw1-[1-2]
+# sw1-1=S2
+SwitchName=sw1-1 Nodes=Node[201-202,205]
+# sw1-2=S3
+SwitchName=sw1-2 Nodes=Node[304-306]
+`, buf.String())
+
+	spec, httpErr := nt.GetNodeTopologySpec("Node305", nil)
+	require.Nil(t, httpErr)
+	require.Equal(t, "default:sw2-1:sw1-2", spec)
+
+	cfg.Naming = topology.Naming{Switches: "sw{tier}"}
+	_, err = NewNetworkTopology(v, cfg)
+	require.EqualError(t, err, `switches "S2" and "S3" have the same name "sw1"`)
+}
+
 func TestToBlockTopology(t *testing.T) {
 	v, _ := getBlockTestSet()
 	cfg := &Config{
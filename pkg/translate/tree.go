@@ -26,7 +26,7 @@ func (nt *NetworkTopology) toTreeTopology(wr io.Writer, skeletonOnly bool) *http
 		if !ok {
 			return httperr.NewError(http.StatusBadGateway, fmt.Sprintf("missing vertex with ID %q", id))
 		}
-		if err := writeVertex(wr, v, skeletonOnly); err != nil {
+		if err := nt.writeVertex(wr, v, skeletonOnly); err != nil {
 			return httperr.NewError(http.StatusInternalServerError, err.Error())
 		}
 		queue = append(queue, nt.tree[id]...)
@@ -34,7 +34,7 @@ func (nt *NetworkTopology) toTreeTopology(wr io.Writer, skeletonOnly bool) *http
 	return nil
 }
 
-func writeVertex(wr io.Writer, v *topology.Vertex, skeletonOnly bool) error {
+func (nt *NetworkTopology) writeVertex(wr io.Writer, v *topology.Vertex, skeletonOnly bool) error {
 	if len(v.ID) == 0 {
 		return nil
 	}
@@ -45,20 +45,14 @@ func writeVertex(wr io.Writer, v *topology.Vertex, skeletonOnly bool) error {
 		if len(w.Vertices) == 0 {
 			nodes = append(nodes, w.Name)
 		} else {
-			if len(w.Name) != 0 {
-				switches = append(switches, w.Name)
-			} else {
-				switches = append(switches, w.ID)
-			}
+			switches = append(switches, nt.vertexName(w))
 		}
 	}
 
-	var comment, name string
-	if len(v.Name) == 0 {
-		name = v.ID
-	} else {
-		comment = fmt.Sprintf("# %s=%s\n", v.Name, v.ID)
-		name = v.Name
+	var comment string
+	name := nt.vertexName(v)
+	if name != v.ID {
+		comment = fmt.Sprintf("# %s=%s\n", name, v.ID)
 	}
 
 	if len(switches) != 0 {
@@ -76,3 +70,23 @@ func writeVertex(wr io.Writer, v *topology.Vertex, skeletonOnly bool) error {
 	}
 	return nil
 }
+
+// switchName returns the name of the switch under the switch naming strategy, or its ID
+func (nt *NetworkTopology) switchName(id string) string {
+	if name, ok := nt.switchNames[id]; ok {
+		return name
+	}
+	return id
+}
+
+// vertexName returns the name of the switch in the tree topology: the name under
+// the switch naming strategy, or by default the provider name falling back to the ID
+func (nt *NetworkTopology) vertexName(v *topology.Vertex) string {
+	if nt.switchNames != nil {
+		return nt.switchName(v.ID)
+	}
+	if len(v.Name) != 0 {
+		return v.Name
+	}
+	return v.ID
+}
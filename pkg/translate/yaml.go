@@ -95,7 +95,10 @@ func (nt *NetworkTopology) GetTopologies() ([]*TopologyUnit, *httperr.Error) {
 			tu := nt.getTreeTopologyUnit(topoName, topoSpec)
 			topologies = append(topologies, tu)
 		case topology.TopologyBlock:
-			tu := nt.getBlockTopologyUnit(topoName, topoSpec)
+			tu, httpErr := nt.getBlockTopologyUnit(topoName, topoSpec)
+			if httpErr != nil {
+				return topologies, httpErr
+			}
 			topologies = append(topologies, tu)
 		case topology.TopologyFlat:
 			topologies = append(topologies, &TopologyUnit{
@@ -132,7 +135,7 @@ func (nt *NetworkTopology) toYamlTopology(wr io.Writer, topologies []*TopologyUn
 	return nil
 }
 
-func (nt *NetworkTopology) getBlockTopologyUnit(topoName string, topoSpec *TopologySpec) *TopologyUnit {
+func (nt *NetworkTopology) getBlockTopologyUnit(topoName string, topoSpec *TopologySpec) (*TopologyUnit, *httperr.Error) {
 	// populate map [block indx : blockInfo]
 	nodeNames := cluset.Expand(topoSpec.Nodes)
 	blockMap := make(map[int]*blockInfo)
@@ -180,18 +183,23 @@ func (nt *NetworkTopology) getBlockTopologyUnit(topoName string, topoSpec *Topol
 			return bInfos[i].indx < bInfos[j].indx
 		})
 
-		bInfos = nt.complementBlocks(bInfos, topoSpec.BlockSizes)
+		bInfos, httpErr := nt.nameBlocks(topoName, nt.complementBlocks(bInfos, topoSpec.BlockSizes), "block%d")
+		if httpErr != nil {
+			return nil, httpErr
+		}
 
 		// populate block topology units ordered by block indices
 		blocks := make([]*Block, 0, len(bInfos))
+		names := make([]string, 0, len(bInfos))
 		parents := make(map[string]string)
-		for indx, bInfo := range bInfos {
-			blockName := fmt.Sprintf("block%d", indx+1)
+		for _, bInfo := range bInfos {
+			blockName := bInfo.id
 			block := &Block{Name: blockName}
 			if len(bInfo.nodes) != 0 {
 				block.Nodes = strings.Join(cluset.Compact(bInfo.nodes), ",")
 			}
 			blocks = append(blocks, block)
+			names = append(names, blockName)
 
 			for _, nodeName := range bInfo.nodes {
 				parents[nodeName] = blockName
@@ -203,8 +211,9 @@ func (nt *NetworkTopology) getBlockTopologyUnit(topoName string, topoSpec *Topol
 			Blocks:     blocks,
 			parents:    parents,
 		}
+		nt.explainBlocks(topoName, bInfos, names, tu.Block.BlockSizes)
 	}
-	return tu
+	return tu, nil
 }
 
 func (nt *NetworkTopology) getTreeTopologyUnit(topoName string, topoSpec *TopologySpec) *TopologyUnit {
@@ -226,7 +235,7 @@ func (nt *NetworkTopology) getTreeTopologyUnit(topoName string, topoSpec *Topolo
 		}
 	}
 	// get partial tree for switches
-	tree := nt.getPartitionTree(nodeIDs)
+	tree := trimTree(nt.getPartitionTree(nodeIDs), topoSpec.TrimTiers, topoSpec.LeafOnly)
 	if len(tree) == 0 {
 		tu.Flat = true
 	} else {
@@ -242,26 +251,22 @@ func (nt *NetworkTopology) getTreeTopologyUnit(topoName string, topoSpec *Topolo
 				continue
 			}
 			if len(switchID) != 0 {
-				v := nt.vertices[switchID]
-				sw := &Switch{Name: v.ID}
+				sw := &Switch{Name: nt.switchName(switchID)}
 				childen := []string{}
 				leaves := []string{}
-				switchSelector := newSelector(connects)
-				for _, w := range v.Vertices {
-					key := w.ID
-					if len(w.Vertices) == 0 {
-						key = w.Name
-						if nodeSelector[w.Name] {
-							leaves = append(leaves, w.Name)
-						}
+				for _, id := range connects {
+					key := id
+					if _, ok := tree[id]; ok {
+						childen = append(childen, nt.switchName(id))
 					} else {
-						if switchSelector[w.ID] {
-							childen = append(childen, w.ID)
+						key = nt.vertices[id].Name
+						if nodeSelector[key] {
+							leaves = append(leaves, key)
 						}
 					}
 					// record parent switch for each child for later use in generating topology spec for each node
-					tu.Tree.parents[key] = append([]string{}, tu.Tree.parents[v.ID]...)
-					tu.Tree.parents[key] = append(tu.Tree.parents[key], v.ID)
+					tu.Tree.parents[key] = append([]string{}, tu.Tree.parents[switchID]...)
+					tu.Tree.parents[key] = append(tu.Tree.parents[key], sw.Name)
 				}
 				if len(childen) != 0 || len(leaves) != 0 {
 					if len(childen) != 0 {
@@ -367,3 +372,72 @@ func getTopologySpec(node string, topologies []*TopologyUnit) (string, *httperr.
 	}
 	return buf.String(), nil
 }
+
+// trimTree removes the trimTiers highest switch tiers from the partition tree, or all
+// switches but the leaf ones if leafOnly is set. The children switches of a removed
+// switch are attached to its closest remaining ancestor, while a removed switch with
+// directly attached nodes is kept as a leaf switch of these nodes.
+func trimTree(tree map[string][]string, trimTiers int, leafOnly bool) map[string][]string {
+	if len(tree) == 0 || (trimTiers <= 0 && !leafOnly) {
+		return tree
+	}
+
+	// compute switch heights above the nodes
+	heights := make(map[string]int)
+	var visit func(string) int
+	visit = func(id string) int {
+		children, ok := tree[id]
+		if !ok {
+			return 0
+		}
+		h := 0
+		for _, child := range children {
+			h = max(h, visit(child)+1)
+		}
+		heights[id] = h
+		return h
+	}
+	// the root is one tier above the top switches
+	maxHeight := visit("") - 1
+
+	trimmed := map[string][]string{"": {}}
+	var attach func(parent, id string)
+	attach = func(parent, id string) {
+		children, isSwitch := tree[id]
+		if !isSwitch {
+			trimmed[parent] = append(trimmed[parent], id)
+			return
+		}
+
+		if !leafOnly && heights[id] <= maxHeight-trimTiers {
+			trimmed[parent] = append(trimmed[parent], id)
+			trimmed[id] = []string{}
+			for _, child := range children {
+				attach(id, child)
+			}
+			return
+		}
+
+		nodes := []string{}
+		for _, child := range children {
+			if _, ok := tree[child]; ok {
+				attach(parent, child)
+			} else {
+				nodes = append(nodes, child)
+			}
+		}
+		if len(nodes) != 0 {
+			trimmed[parent] = append(trimmed[parent], id)
+			trimmed[id] = nodes
+		}
+	}
+	for _, child := range tree[""] {
+		attach("", child)
+	}
+
+	for _, children := range trimmed {
+		sort.Strings(children)
+	}
+
+	return trimmed
+}
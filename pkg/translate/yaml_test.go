@@ -132,6 +132,60 @@ func TestBlockYamlTopology(t *testing.T) {
 	require.Equal(t, expectedSkeleton, buf.String())
 }
 
+func TestYamlTopologyNaming(t *testing.T) {
+	expected := `- topology: topo1
+  cluster_default: false
+  block:
+    block_sizes:
+        - 2
+        - 4
+    blocks:
+        - block: block-0bdd3c5c
+          nodes: Node[104-106]
+        - block: block-0edd4115
+          nodes: Node[201-202]
+- topology: topo2
+  cluster_default: false
+  tree:
+    switches:
+        - switch: core-IB1
+          children: core-S4
+        - switch: core-S4
+          children: core-S5
+        - switch: core-S5
+          nodes: Node[301-303]
+`
+
+	v, _ := GetBlockWithMultiIBTestSet()
+	cfg := &Config{
+		Topologies: map[string]*TopologySpec{
+			"topo1": {
+				Plugin: topology.TopologyBlock,
+				Nodes:  []string{"Node[104-106,201-202]"},
+			},
+			"topo2": {
+				Plugin: topology.TopologyTree,
+				Nodes:  []string{"Node[301-303]"},
+			},
+		},
+		Naming: topology.Naming{Switches: "core-{id}", Blocks: topology.NamingHash},
+	}
+	nt, err := NewNetworkTopology(v, cfg)
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	topologies, httpErr := nt.GenerateTopologyConfig(buf, false)
+	require.Nil(t, httpErr)
+	require.Equal(t, expected, buf.String())
+
+	spec, httpErr := nt.GetNodeTopologySpec("Node202", topologies)
+	require.Nil(t, httpErr)
+	require.Equal(t, "topo1:block-0edd4115", spec)
+
+	spec, httpErr = nt.GetNodeTopologySpec("Node302", topologies)
+	require.Nil(t, httpErr)
+	require.Equal(t, "topo2:core-IB1:core-S4:core-S5", spec)
+}
+
 func TestMixedYamlTopology(t *testing.T) {
 	expected := `- topology: topo1
   cluster_default: false
@@ -405,3 +459,137 @@ func TestGetBlockTopologyUnitComplementEmptyNodes(t *testing.T) {
 	require.Nil(t, nt.Generate(buf))
 	require.Equal(t, expected, buf.String())
 }
+
+func TestTrimmedTreeYamlTopology(t *testing.T) {
+	expected := `- topology: inference
+  cluster_default: false
+  tree:
+    switches:
+        - switch: S5
+          nodes: Node[301-303]
+        - switch: S6
+          nodes: Node[401-403]
+- topology: mid
+  cluster_default: false
+  tree:
+    switches:
+        - switch: S1
+          children: S[2-3]
+        - switch: S2
+          nodes: Node104
+        - switch: S3
+          nodes: Node201
+- topology: training
+  cluster_default: true
+  tree:
+    switches:
+        - switch: IB2
+          children: S1
+        - switch: S1
+          children: S[2-3]
+        - switch: S2
+          nodes: Node[104-106]
+        - switch: S3
+          nodes: Node[201-202,205]
+`
+	v, _ := GetBlockWithMultiIBTestSet()
+	cfg := &Config{
+		Topologies: map[string]*TopologySpec{
+			"training": {
+				Plugin:         topology.TopologyTree,
+				Nodes:          []string{"Node[104-106,201-202,205]"},
+				ClusterDefault: true,
+			},
+			"inference": {
+				Plugin:   topology.TopologyTree,
+				Nodes:    []string{"Node[301-303,401-403]"},
+				LeafOnly: true,
+			},
+			"mid": {
+				Plugin:    topology.TopologyTree,
+				Nodes:     []string{"Node104", "Node201"},
+				TrimTiers: 1,
+			},
+		},
+	}
+	nt, err := NewNetworkTopology(v, cfg)
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	topologies, httpErr := nt.GenerateTopologyConfig(buf, false)
+	require.Nil(t, httpErr)
+	require.Equal(t, expected, buf.String())
+
+	for node, spec := range map[string]string{
+		"Node104": "mid:S1:S2,training:IB2:S1:S2",
+		"Node105": "training:IB2:S1:S2",
+		"Node301": "inference:S5",
+	} {
+		actual, httpErr := nt.GetNodeTopologySpec(node, topologies)
+		require.Nil(t, httpErr)
+		require.Equal(t, spec, actual)
+	}
+}
+
+func TestTrimTree(t *testing.T) {
+	tree := map[string][]string{
+		"":   {"C1"},
+		"C1": {"S1", "S2"},
+		"S1": {"L1", "L2"},
+		"S2": {"L3", "n5"},
+		"L1": {"n1", "n2"},
+		"L2": {"n3"},
+		"L3": {"n4"},
+	}
+
+	testCases := []struct {
+		name      string
+		trimTiers int
+		leafOnly  bool
+		expected  map[string][]string
+	}{
+		{
+			name:     "Case 1: no trimming",
+			expected: tree,
+		},
+		{
+			name:      "Case 2: trim one tier",
+			trimTiers: 1,
+			expected: map[string][]string{
+				"":   {"S1", "S2"},
+				"S1": {"L1", "L2"},
+				"S2": {"L3", "n5"},
+				"L1": {"n1", "n2"},
+				"L2": {"n3"},
+				"L3": {"n4"},
+			},
+		},
+		{
+			name:      "Case 3: trim all tiers",
+			trimTiers: 5,
+			expected: map[string][]string{
+				"":   {"L1", "L2", "L3", "S2"},
+				"S2": {"n5"},
+				"L1": {"n1", "n2"},
+				"L2": {"n3"},
+				"L3": {"n4"},
+			},
+		},
+		{
+			name:     "Case 4: leaf only",
+			leafOnly: true,
+			expected: map[string][]string{
+				"":   {"L1", "L2", "L3", "S2"},
+				"S2": {"n5"},
+				"L1": {"n1", "n2"},
+				"L2": {"n3"},
+				"L3": {"n4"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, trimTree(tree, tc.trimTiers, tc.leafOnly))
+		})
+	}
+}